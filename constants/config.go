@@ -0,0 +1,37 @@
+package constants
+
+const (
+	LogLevelEnvVar          = "YOLO_AGENT_CONTAINER_LOG_LEVEL"
+	LogFormatEnvVar         = "YOLO_AGENT_CONTAINER_LOG_FORMAT"
+	LogFileEnvVar           = "YOLO_AGENT_CONTAINER_LOG_FILE"
+	LogFileMaxSizeMBEnvVar  = "YOLO_AGENT_CONTAINER_LOG_FILE_MAX_SIZE_MB"
+	LogFileMaxBackupsEnvVar = "YOLO_AGENT_CONTAINER_LOG_FILE_MAX_BACKUPS"
+	LogBufferSizeEnvVar     = "YOLO_AGENT_CONTAINER_LOG_BUFFER_SIZE"
+
+	MetricsServerAddrEnvVar = "YOLO_AGENT_CONTAINER_METRICS_ADDR"
+
+	ProxyLingerTimeoutEnvVar   = "YOLO_AGENT_CONTAINER_PROXY_LINGER_TIMEOUT"
+	ProxyIdleTimeoutEnvVar     = "YOLO_AGENT_CONTAINER_PROXY_IDLE_TIMEOUT"
+	ProxyKeepAlivePeriodEnvVar = "YOLO_AGENT_CONTAINER_PROXY_KEEP_ALIVE_PERIOD"
+
+	UnixSocketsDirsEnvVar = "YOLO_AGENT_CONTAINER_UNIX_SOCKETS_DIRS"
+
+	ProxyBindAddrsEnvVar = "YOLO_AGENT_CONTAINER_PROXY_BIND_ADDRS"
+
+	SendProxyProtocolPortsEnvVar = "YOLO_AGENT_CONTAINER_SEND_PROXY_PROTOCOL_PORTS"
+	AcceptProxyProtocolEnvVar    = "YOLO_AGENT_CONTAINER_ACCEPT_PROXY_PROTOCOL"
+
+	ProxyMaxConnsPerPortEnvVar   = "YOLO_AGENT_CONTAINER_PROXY_MAX_CONNS_PER_PORT"
+	ProxyMaxFDsPerPortEnvVar     = "YOLO_AGENT_CONTAINER_PROXY_MAX_FDS_PER_PORT"
+	ProxyMaxFDsTotalEnvVar       = "YOLO_AGENT_CONTAINER_PROXY_MAX_FDS_TOTAL"
+	ProxyAcceptRatePerPortEnvVar = "YOLO_AGENT_CONTAINER_PROXY_ACCEPT_RATE_PER_PORT"
+	ProxyAcceptBurstEnvVar       = "YOLO_AGENT_CONTAINER_PROXY_ACCEPT_BURST_PER_PORT"
+
+	HTTPProxyAddrEnvVar          = "YOLO_AGENT_CONTAINER_HTTP_PROXY_ADDR"
+	HTTPProxyWorkspaceNameEnvVar = "YOLO_AGENT_CONTAINER_HTTP_PROXY_WORKSPACE_NAME"
+	HTTPSProxyAddrEnvVar         = "YOLO_AGENT_CONTAINER_HTTPS_PROXY_ADDR"
+
+	GitAccessCheckMaxAttemptsEnvVar    = "YOLO_AGENT_CONTAINER_GIT_ACCESS_CHECK_MAX_ATTEMPTS"
+	GitAccessCheckInitialBackoffEnvVar = "YOLO_AGENT_CONTAINER_GIT_ACCESS_CHECK_INITIAL_BACKOFF"
+	GitAccessCheckMaxBackoffEnvVar     = "YOLO_AGENT_CONTAINER_GIT_ACCESS_CHECK_MAX_BACKOFF"
+)
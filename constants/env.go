@@ -19,6 +19,31 @@ const (
 
 	VSCodeWorkspaceConfigFilePath = WorkspaceConfigDirPath + "/default.code-workspace"
 
+	PortForwardingRulesFilePath = YoloConfigDirPath + "/port-forwarding-rules.json"
+
+	AgentLogsDirPath = YoloConfigDirPath + "/logs"
+	AgentLogFilePath = AgentLogsDirPath + "/agent-container.log"
+
+	InitRunsDirPath = YoloConfigDirPath + "/init-runs"
+
+	WorkspaceLockFilePath = YoloConfigDirPath + "/workspace.lock"
+
+	GitCredentialHelperPath = "/usr/local/bin/git-credential-yolo"
+
+	// Private to the "yolo" user (unlike
+	// "/yolo-config" that is shared with the host)
+	GitCredentialsSocketDirPath = "/run/yolo-git-credentials"
+	GitCredentialsSocketPath    = GitCredentialsSocketDirPath + "/credentials.sock"
+
+	// Private to the "yolo" user (unlike
+	// "/yolo-config" that is shared with the host)
+	SSHAgentSocketDirPath = "/run/yolo-ssh-agent"
+	SSHAgentSocketPath    = SSHAgentSocketDirPath + "/agent.sock"
+
+	WorkspaceCADirPath      = YoloConfigDirPath + "/tls"
+	WorkspaceCACertFilePath = WorkspaceCADirPath + "/ca.crt"
+	WorkspaceCAKeyFilePath  = WorkspaceCADirPath + "/ca.key"
+
 	GitHubPublicSSHKeyFilePath = YoloUserHomeDirPath + "/.ssh/" + YoloUserName + "-github.pub"
 	GitHubPublicGPGKeyFilePath = YoloUserHomeDirPath + "/.gnupg/" + YoloUserName + "-github-gpg-public.pgp"
 )
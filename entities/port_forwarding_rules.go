@@ -0,0 +1,80 @@
+package entities
+
+import (
+	"encoding/json"
+	"os"
+)
+
+type PortForwardingRules struct {
+	Rules []PortForwardingRule `json:"rules"`
+}
+
+type PortForwardingRule struct {
+	// The port exposed on the container IP address
+	ExternalPort uint64 `json:"external_port"`
+	// "tcp" or "unix"
+	TargetNetwork string `json:"target_network"`
+	// An "host:port" address for "tcp", a socket path for "unix"
+	TargetAddr string `json:"target_addr"`
+	// Terminate TLS using the workspace CA
+	// before forwarding the traffic
+	TLS bool `json:"tls,omitempty"`
+	// Prepend a PROXY protocol v2 header to the traffic
+	// sent to the target so that it sees the real client address
+	SendProxyProtocol bool `json:"send_proxy_protocol,omitempty"`
+}
+
+func NewPortForwardingRules() *PortForwardingRules {
+	return &PortForwardingRules{
+		Rules: []PortForwardingRule{},
+	}
+}
+
+func LoadPortForwardingRules(
+	portForwardingRulesFilePath string,
+) (*PortForwardingRules, error) {
+
+	portForwardingRulesFileContent, err := os.ReadFile(portForwardingRulesFilePath)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var portForwardingRules *PortForwardingRules
+	err = json.Unmarshal(portForwardingRulesFileContent, &portForwardingRules)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return portForwardingRules, nil
+}
+
+func SavePortForwardingRulesAsFile(
+	portForwardingRulesFilePath string,
+	portForwardingRules *PortForwardingRules,
+) error {
+
+	portForwardingRulesAsJSON, err := json.Marshal(portForwardingRules)
+
+	if err != nil {
+		return err
+	}
+
+	err = os.WriteFile(
+		portForwardingRulesFilePath,
+		portForwardingRulesAsJSON,
+		os.FileMode(0660),
+	)
+
+	if err != nil {
+		return err
+	}
+
+	// Overwrite umask.
+	// See: https://stackoverflow.com/questions/50257981/ioutils-writefile-not-respecting-permissions
+	return os.Chmod(
+		portForwardingRulesFilePath,
+		0660,
+	)
+}
@@ -0,0 +1,590 @@
+package config
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/yolo-sh/agent-container/constants"
+	"github.com/yolo-sh/agent-container/internal/env"
+	"github.com/yolo-sh/agent-container/internal/network"
+)
+
+// Config holds the container agent settings.
+// Each setting could be overridden using
+// the environment variables defined in "constants".
+type Config struct {
+	// "debug", "info", "warn" or "error"
+	LogLevel string
+	// "text" or "json"
+	LogFormat string
+	// The file the logs are also written to (so that they
+	// could be collected by the agent in the host).
+	// Disabled when empty.
+	LogFilePath string
+	// The size above which the log file is rotated
+	LogFileMaxSizeMB int
+	// The number of rotated log files kept
+	LogFileMaxBackups int
+	// The number of recent log records kept in
+	// memory to be streamed to the host (see "StreamLogs")
+	LogBufferSize int
+
+	// The TCP address (eg: "172.20.0.2:9100")
+	// the Prometheus metrics endpoint will listen on.
+	// The endpoint is disabled when empty.
+	MetricsServerAddr string
+
+	// How long a proxied connection is kept open
+	// once one side has closed its write half,
+	// waiting for the other side to finish.
+	ProxyLingerTimeout time.Duration
+	// How long a proxied connection could stay
+	// without any traffic before being closed.
+	// Zero disables the idle timeout.
+	ProxyIdleTimeout time.Duration
+	// The TCP keep-alive period applied to both ends
+	// of the proxied connections.
+	// A negative value disables keep-alives.
+	ProxyKeepAlivePeriod time.Duration
+
+	// The directories the listening Unix sockets
+	// are discovered in. Set as a colon-separated list
+	// (eg: "/tmp:/run") in the environment variable.
+	UnixSocketsDirs []string
+
+	// The container addresses (IPv4 and/or IPv6) the proxies listen on.
+	// Set as a comma-separated list (eg: "172.20.0.2,fd00::2")
+	// in the environment variable. Discovered from the
+	// container interfaces when empty.
+	ProxyBindAddrs []string
+
+	// The auto-detected services on these ports receive a PROXY
+	// protocol v2 header so that they see the real client address.
+	// Set as a comma-separated list (eg: "3000,8080")
+	// in the environment variable.
+	SendProxyProtocolPorts []uint64
+	// Expect a PROXY protocol header (v1 or v2) at the start
+	// of the proxied connections (eg: behind a load balancer).
+	AcceptProxyProtocol bool
+
+	// The maximum number of concurrent
+	// connections per forwarded port.
+	// Zero disables the limit.
+	ProxyMaxConnsPerPort int
+	// The maximum number of file descriptors held by the
+	// connections of each forwarded port (two per connection).
+	// Zero disables the limit.
+	ProxyMaxFDsPerPort int
+	// The maximum number of file descriptors held by the
+	// connections of all the forwarded ports. Zero derives it
+	// from the "RLIMIT_NOFILE" soft limit, a negative value
+	// disables the limit.
+	ProxyMaxFDsTotal int
+	// The maximum number of connections accepted per second,
+	// per forwarded port. Zero disables the limit.
+	ProxyAcceptRatePerPort float64
+	// The number of connections accepted in
+	// a burst above "ProxyAcceptRatePerPort".
+	ProxyAcceptBurstPerPort int
+
+	// The TCP address (eg: "172.20.0.2:80") of the HTTP
+	// entrypoint that routes the requests to the forwarded
+	// ports by hostname. Disabled when empty.
+	HTTPProxyAddr string
+	// When set, only the "<port>.<HTTPProxyWorkspaceName>.localhost"
+	// hostnames are routed by the HTTP entrypoint.
+	HTTPProxyWorkspaceName string
+	// The TCP address (eg: "172.20.0.2:443") of the HTTPS
+	// variant of the HTTP entrypoint. TLS is terminated using
+	// the workspace CA. Disabled when empty.
+	HTTPSProxyAddr string
+
+	// The maximum number of "git ls-remote" run to check that the
+	// repository could be accessed before cloning it. Only the
+	// authentication and network errors are retried (eg: GitHub
+	// takes a few seconds to accept a newly added SSH key).
+	GitAccessCheckMaxAttempts int
+	// The delay before the first retry. Doubled after each retry.
+	GitAccessCheckInitialBackoff time.Duration
+	// The maximum delay between two retries
+	GitAccessCheckMaxBackoff time.Duration
+}
+
+// NewDefaultConfig derives the proxies and git
+// defaults from the packages that use them so
+// that they are only defined in one place.
+func NewDefaultConfig() *Config {
+	proxyManagerConfig := network.NewDefaultProxyManagerConfig()
+	gitAccessCheckPolicy := env.NewDefaultGitAccessCheckPolicy()
+
+	return &Config{
+		LogLevel:          "info",
+		LogFormat:         "text",
+		LogFilePath:       constants.AgentLogFilePath,
+		LogFileMaxSizeMB:  10,
+		LogFileMaxBackups: 5,
+		LogBufferSize:     1000,
+
+		MetricsServerAddr: "",
+
+		ProxyLingerTimeout:   proxyManagerConfig.Forwarding.LingerTimeout,
+		ProxyIdleTimeout:     proxyManagerConfig.Forwarding.IdleTimeout,
+		ProxyKeepAlivePeriod: proxyManagerConfig.Forwarding.KeepAlivePeriod,
+
+		UnixSocketsDirs: proxyManagerConfig.UnixSocketsDirs,
+
+		ProxyBindAddrs: proxyManagerConfig.BindAddrs,
+
+		SendProxyProtocolPorts: proxyManagerConfig.SendProxyProtocolPorts,
+		AcceptProxyProtocol:    proxyManagerConfig.AcceptProxyProtocol,
+
+		ProxyMaxConnsPerPort:    proxyManagerConfig.ConnLimits.MaxConnsPerPort,
+		ProxyMaxFDsPerPort:      proxyManagerConfig.ConnLimits.MaxFDsPerPort,
+		ProxyMaxFDsTotal:        proxyManagerConfig.ConnLimits.MaxFDsTotal,
+		ProxyAcceptRatePerPort:  proxyManagerConfig.ConnLimits.AcceptRatePerPort,
+		ProxyAcceptBurstPerPort: proxyManagerConfig.ConnLimits.AcceptBurstPerPort,
+
+		GitAccessCheckMaxAttempts:    gitAccessCheckPolicy.MaxAttempts,
+		GitAccessCheckInitialBackoff: gitAccessCheckPolicy.InitialBackoff,
+		GitAccessCheckMaxBackoff:     gitAccessCheckPolicy.MaxBackoff,
+	}
+}
+
+func Load() (*Config, error) {
+	config := NewDefaultConfig()
+
+	lookupString(
+		constants.LogFileEnvVar,
+		&config.LogFilePath,
+	)
+
+	lookupString(
+		constants.MetricsServerAddrEnvVar,
+		&config.MetricsServerAddr,
+	)
+
+	lookupString(
+		constants.HTTPProxyAddrEnvVar,
+		&config.HTTPProxyAddr,
+	)
+
+	lookupString(
+		constants.HTTPProxyWorkspaceNameEnvVar,
+		&config.HTTPProxyWorkspaceName,
+	)
+
+	lookupString(
+		constants.HTTPSProxyAddrEnvVar,
+		&config.HTTPSProxyAddr,
+	)
+
+	err := lookupEnum(
+		constants.LogLevelEnvVar,
+		&config.LogLevel,
+		"debug",
+		"info",
+		"warn",
+		"error",
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = lookupEnum(
+		constants.LogFormatEnvVar,
+		&config.LogFormat,
+		"text",
+		"json",
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = lookupInt(
+		constants.LogFileMaxSizeMBEnvVar,
+		&config.LogFileMaxSizeMB,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = lookupInt(
+		constants.LogFileMaxBackupsEnvVar,
+		&config.LogFileMaxBackups,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = lookupInt(
+		constants.LogBufferSizeEnvVar,
+		&config.LogBufferSize,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = lookupDuration(
+		constants.ProxyLingerTimeoutEnvVar,
+		&config.ProxyLingerTimeout,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = lookupDuration(
+		constants.ProxyIdleTimeoutEnvVar,
+		&config.ProxyIdleTimeout,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = lookupDuration(
+		constants.ProxyKeepAlivePeriodEnvVar,
+		&config.ProxyKeepAlivePeriod,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = lookupPaths(
+		constants.UnixSocketsDirsEnvVar,
+		&config.UnixSocketsDirs,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = lookupIPs(
+		constants.ProxyBindAddrsEnvVar,
+		&config.ProxyBindAddrs,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = lookupPorts(
+		constants.SendProxyProtocolPortsEnvVar,
+		&config.SendProxyProtocolPorts,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = lookupBool(
+		constants.AcceptProxyProtocolEnvVar,
+		&config.AcceptProxyProtocol,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = lookupInt(
+		constants.ProxyMaxConnsPerPortEnvVar,
+		&config.ProxyMaxConnsPerPort,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = lookupInt(
+		constants.ProxyMaxFDsPerPortEnvVar,
+		&config.ProxyMaxFDsPerPort,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = lookupInt(
+		constants.ProxyMaxFDsTotalEnvVar,
+		&config.ProxyMaxFDsTotal,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = lookupInt(
+		constants.ProxyAcceptBurstEnvVar,
+		&config.ProxyAcceptBurstPerPort,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = lookupFloat(
+		constants.ProxyAcceptRatePerPortEnvVar,
+		&config.ProxyAcceptRatePerPort,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = lookupInt(
+		constants.GitAccessCheckMaxAttemptsEnvVar,
+		&config.GitAccessCheckMaxAttempts,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = lookupDuration(
+		constants.GitAccessCheckInitialBackoffEnvVar,
+		&config.GitAccessCheckInitialBackoff,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = lookupDuration(
+		constants.GitAccessCheckMaxBackoffEnvVar,
+		&config.GitAccessCheckMaxBackoff,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return config, nil
+}
+
+func lookupString(envVar string, value *string) {
+	if envVarValue, ok := os.LookupEnv(envVar); ok {
+		*value = envVarValue
+	}
+}
+
+func lookupDuration(envVar string, value *time.Duration) error {
+	envVarValue, ok := os.LookupEnv(envVar)
+
+	if !ok {
+		return nil
+	}
+
+	duration, err := time.ParseDuration(envVarValue)
+
+	if err != nil {
+		return fmt.Errorf(
+			"invalid duration \"%s\" for %s: %v",
+			envVarValue,
+			envVar,
+			err,
+		)
+	}
+
+	*value = duration
+
+	return nil
+}
+
+func lookupPaths(envVar string, value *[]string) error {
+	envVarValue, ok := os.LookupEnv(envVar)
+
+	if !ok {
+		return nil
+	}
+
+	paths := []string{}
+
+	for _, path := range strings.Split(envVarValue, ":") {
+		if len(path) == 0 {
+			continue
+		}
+
+		if !filepath.IsAbs(path) {
+			return fmt.Errorf(
+				"invalid path \"%s\" for %s: path must be absolute",
+				path,
+				envVar,
+			)
+		}
+
+		paths = append(paths, path)
+	}
+
+	*value = paths
+
+	return nil
+}
+
+func lookupIPs(envVar string, value *[]string) error {
+	envVarValue, ok := os.LookupEnv(envVar)
+
+	if !ok {
+		return nil
+	}
+
+	ips := []string{}
+
+	for _, ip := range strings.Split(envVarValue, ",") {
+		ip = strings.TrimSpace(ip)
+
+		if len(ip) == 0 {
+			continue
+		}
+
+		parsedIP := net.ParseIP(ip)
+
+		if parsedIP == nil {
+			return fmt.Errorf(
+				"invalid IP address \"%s\" for %s",
+				ip,
+				envVar,
+			)
+		}
+
+		ips = append(ips, parsedIP.String())
+	}
+
+	*value = ips
+
+	return nil
+}
+
+func lookupPorts(envVar string, value *[]uint64) error {
+	envVarValue, ok := os.LookupEnv(envVar)
+
+	if !ok {
+		return nil
+	}
+
+	ports := []uint64{}
+
+	for _, port := range strings.Split(envVarValue, ",") {
+		port = strings.TrimSpace(port)
+
+		if len(port) == 0 {
+			continue
+		}
+
+		parsedPort, err := strconv.ParseUint(port, 10, 16)
+
+		if err != nil || parsedPort == 0 {
+			return fmt.Errorf(
+				"invalid port \"%s\" for %s",
+				port,
+				envVar,
+			)
+		}
+
+		ports = append(ports, parsedPort)
+	}
+
+	*value = ports
+
+	return nil
+}
+
+func lookupBool(envVar string, value *bool) error {
+	envVarValue, ok := os.LookupEnv(envVar)
+
+	if !ok {
+		return nil
+	}
+
+	parsedValue, err := strconv.ParseBool(envVarValue)
+
+	if err != nil {
+		return fmt.Errorf(
+			"invalid boolean \"%s\" for %s: %v",
+			envVarValue,
+			envVar,
+			err,
+		)
+	}
+
+	*value = parsedValue
+
+	return nil
+}
+
+func lookupInt(envVar string, value *int) error {
+	envVarValue, ok := os.LookupEnv(envVar)
+
+	if !ok {
+		return nil
+	}
+
+	parsedValue, err := strconv.Atoi(envVarValue)
+
+	if err != nil {
+		return fmt.Errorf(
+			"invalid integer \"%s\" for %s: %v",
+			envVarValue,
+			envVar,
+			err,
+		)
+	}
+
+	*value = parsedValue
+
+	return nil
+}
+
+func lookupFloat(envVar string, value *float64) error {
+	envVarValue, ok := os.LookupEnv(envVar)
+
+	if !ok {
+		return nil
+	}
+
+	parsedValue, err := strconv.ParseFloat(envVarValue, 64)
+
+	if err != nil || parsedValue < 0 {
+		return fmt.Errorf(
+			"invalid positive number \"%s\" for %s",
+			envVarValue,
+			envVar,
+		)
+	}
+
+	*value = parsedValue
+
+	return nil
+}
+
+func lookupEnum(envVar string, value *string, allowedValues ...string) error {
+	envVarValue, ok := os.LookupEnv(envVar)
+
+	if !ok {
+		return nil
+	}
+
+	for _, allowedValue := range allowedValues {
+		if envVarValue == allowedValue {
+			*value = envVarValue
+			return nil
+		}
+	}
+
+	return fmt.Errorf(
+		"invalid value \"%s\" for %s: must be one of \"%s\"",
+		envVarValue,
+		envVar,
+		strings.Join(allowedValues, "\", \""),
+	)
+}
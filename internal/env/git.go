@@ -2,68 +2,180 @@ package env
 
 import (
 	"bytes"
+	"context"
 	"fmt"
+	"os"
 	"os/exec"
-	"regexp"
 	"strings"
 	"time"
 
-	"github.com/yolo-sh/yolo/github"
+	"github.com/yolo-sh/agent-container/internal/logging"
+	"github.com/yolo-sh/agent-container/internal/system"
 )
 
+var logger = logging.Component(logging.ComponentEnv)
+
+const (
+	GitTransportSSH      = "ssh"
+	GitTransportSSHAgent = "ssh_agent"
+	GitTransportHTTPS    = "https"
+)
+
+// GitAuth configures how the GitHub repositories are accessed
+type GitAuth struct {
+	// The git credential helper used to access the repositories
+	// over HTTPS (eg: "/usr/local/bin/git-credential-yolo").
+	// The repositories are accessed over SSH, with the
+	// generated key, when empty.
+	HTTPSCredentialHelper string
+	// The socket of the SSH agent forwarded by the host agent,
+	// used instead of the generated key when set.
+	SSHAgentSocketPath string
+}
+
+func (g GitAuth) Transport() string {
+	if len(g.HTTPSCredentialHelper) > 0 {
+		return GitTransportHTTPS
+	}
+
+	if len(g.SSHAgentSocketPath) > 0 {
+		return GitTransportSSHAgent
+	}
+
+	return GitTransportSSH
+}
+
+const (
+	cloneMaxAttempts   = 3
+	cloneRetryInterval = 4 * time.Second
+)
+
+// The access to the repository is checked (and
+// retried) before cloning it. See "CheckGitHubRepoAccess".
+// The clone is still retried, for the same errors, given that
+// GitHub may not accept a newly added SSH key consistently.
 func cloneGitHubRepo(
+	ctx context.Context,
 	repoOwner string,
 	repoName string,
+	gitAuth GitAuth,
 	cloneDir string,
 ) error {
 
-	maxRetries := 3
-	retriesInterval := 4 * time.Second
-	var lastErrorReturned error
+	for attempt := 1; ; attempt++ {
+		err := cloneGitHubRepoOnce(
+			ctx,
+			repoOwner,
+			repoName,
+			gitAuth,
+			cloneDir,
+		)
+
+		if err == nil || ctx.Err() != nil ||
+			!isGitAccessErrRetryable(err, gitAuth) || attempt >= cloneMaxAttempts {
 
-	// Avoid a GitHub race condition that
-	// prevent an SSH key pair to be used
-	// just after creation
-	for retry := 0; retry < maxRetries; retry++ {
-		githubGitURL := github.BuildGitURL(repoOwner, repoName)
+			return err
+		}
 
-		cmd := exec.Command(
-			"git",
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(cloneRetryInterval):
+		}
+	}
+}
+
+func cloneGitHubRepoOnce(
+	ctx context.Context,
+	repoOwner string,
+	repoName string,
+	gitAuth GitAuth,
+	cloneDir string,
+) error {
+
+	cmd := buildGitCmd(
+		ctx,
+		gitAuth,
+		"clone",
+		"--quiet",
+		buildGitHubRepoURL(repoOwner, repoName, gitAuth),
+		cloneDir,
+	)
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	err := cmd.Run()
+
+	if err != nil && ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	if err != nil {
+		logger.WarnContext(
+			ctx,
+			"error when cloning repository",
+			"repo_owner", repoOwner,
+			"repo_name", repoName,
+			"error", strings.TrimSpace(stderr.String()),
+		)
+
+		return newGitError(
 			"clone",
-			"--quiet",
-			string(githubGitURL),
-			cloneDir,
+			gitAuth.Transport(),
+			repoOwner,
+			repoName,
+			stderr.String(),
+			err,
 		)
+	}
 
-		var stdout bytes.Buffer
-		var stderr bytes.Buffer
+	return nil
+}
 
-		cmd.Stdout = &stdout
-		cmd.Stderr = &stderr
+func buildGitHubRepoURL(
+	repoOwner string,
+	repoName string,
+	gitAuth GitAuth,
+) string {
 
-		err := cmd.Run()
+	if gitAuth.Transport() == GitTransportHTTPS {
+		return fmt.Sprintf("https://github.com/%s/%s.git", repoOwner, repoName)
+	}
 
-		if err != nil {
-			newLineRegExp := regexp.MustCompile(`\n+`)
+	return fmt.Sprintf("git@github.com:%s/%s.git", repoOwner, repoName)
+}
 
-			lastErrorReturned = fmt.Errorf(
-				"error while cloning the repository \"%s/%s\".\n\n%s\n\n%s",
-				repoOwner,
-				repoName,
-				strings.TrimSpace(
-					newLineRegExp.ReplaceAllLiteralString(stderr.String(), " "),
-				),
-				err.Error(),
-			)
+// buildGitCmd builds a git command that fails instead of
+// prompting for a password or a host key confirmation
+func buildGitCmd(
+	ctx context.Context,
+	gitAuth GitAuth,
+	args ...string,
+) *exec.Cmd {
 
-			time.Sleep(retriesInterval)
+	gitArgs := []string{}
 
-			continue
-		}
+	if gitAuth.Transport() == GitTransportHTTPS {
+		gitArgs = append(
+			gitArgs,
+			// The empty value resets the configured helpers
+			"-c", "credential.helper=",
+			"-c", "credential.helper="+gitAuth.HTTPSCredentialHelper,
+		)
+	}
+
+	cmd := system.CommandContext(ctx, "git", append(gitArgs, args...)...)
+
+	cmd.Env = append(
+		os.Environ(),
+		"GIT_TERMINAL_PROMPT=0",
+		"GIT_SSH_COMMAND=ssh -o BatchMode=yes -o ConnectTimeout=10",
+	)
 
-		lastErrorReturned = nil
-		break
+	if gitAuth.Transport() == GitTransportSSHAgent {
+		cmd.Env = append(cmd.Env, "SSH_AUTH_SOCK="+gitAuth.SSHAgentSocketPath)
 	}
 
-	return lastErrorReturned
+	return cmd
 }
@@ -0,0 +1,129 @@
+package env
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// The classes of the git errors (see "GitError")
+var (
+	ErrGitHostKeyMismatch    = errors.New("git host key mismatch")
+	ErrGitHostKeyUnknown     = errors.New("git host key unknown")
+	ErrGitAuthFailed         = errors.New("git authentication failed")
+	ErrGitRepoNotFound       = errors.New("git repository not found")
+	ErrGitNetworkUnreachable = errors.New("git remote unreachable")
+)
+
+// The number of stderr lines kept in the errors
+const gitStderrTailMaxLines = 20
+
+var newLineRegExp = regexp.MustCompile(`\n+`)
+
+// The git outputs are matched in lower case
+var gitErrorPatterns = []struct {
+	substring string
+	class     error
+}{
+	// Before the authentication errors given that
+	// ssh fails to authenticate in these cases too.
+	// "Host key verification failed" is printed when
+	// the host key has changed and when it is unknown
+	// (eg: "No ED25519 host key is known for github.com")
+	// so the precise messages are matched first.
+	{"remote host identification has changed", ErrGitHostKeyMismatch},
+	{"host key is known for", ErrGitHostKeyUnknown},
+	{"host key verification failed", ErrGitHostKeyMismatch},
+	{"permission denied (publickey", ErrGitAuthFailed},
+	{"authentication failed", ErrGitAuthFailed},
+	{"could not read username", ErrGitAuthFailed},
+	{"the requested url returned error: 403", ErrGitAuthFailed},
+	{"repository not found", ErrGitRepoNotFound},
+	{"does not appear to be a git repository", ErrGitRepoNotFound},
+	{"could not resolve hostname", ErrGitNetworkUnreachable},
+	{"could not resolve host", ErrGitNetworkUnreachable},
+	{"network is unreachable", ErrGitNetworkUnreachable},
+	{"connection timed out", ErrGitNetworkUnreachable},
+	{"connection refused", ErrGitNetworkUnreachable},
+	{"failed to connect to", ErrGitNetworkUnreachable},
+	{"operation timed out", ErrGitNetworkUnreachable},
+}
+
+// GitError is returned when a git command
+// run on a repository has failed.
+type GitError struct {
+	// The command (eg: "clone")
+	Command string
+	// "ssh" or "https"
+	Transport string
+	RepoOwner string
+	RepoName  string
+	// One of the "ErrGit*" errors. Nil when unknown.
+	Class error
+	// The last lines written by git to stderr
+	StderrTail string
+	Err        error
+}
+
+func newGitError(
+	command string,
+	transport string,
+	repoOwner string,
+	repoName string,
+	stderr string,
+	err error,
+) *GitError {
+
+	return &GitError{
+		Command:    command,
+		Transport:  transport,
+		RepoOwner:  repoOwner,
+		RepoName:   repoName,
+		Class:      classifyGitStderr(stderr),
+		StderrTail: tailLines(strings.TrimSpace(stderr), gitStderrTailMaxLines),
+		Err:        err,
+	}
+}
+
+func (g *GitError) Error() string {
+	return fmt.Sprintf(
+		"error while running \"git %s\" on the repository \"%s/%s\".\n\n%s\n\n%s",
+		g.Command,
+		g.RepoOwner,
+		g.RepoName,
+		newLineRegExp.ReplaceAllLiteralString(g.StderrTail, " "),
+		g.Err.Error(),
+	)
+}
+
+// Unwrap lets "errors.Is" match the class of the error
+func (g *GitError) Unwrap() []error {
+	if g.Class == nil {
+		return []error{g.Err}
+	}
+
+	return []error{g.Class, g.Err}
+}
+
+func classifyGitStderr(stderr string) error {
+	lowerStderr := strings.ToLower(stderr)
+
+	for _, pattern := range gitErrorPatterns {
+		if strings.Contains(lowerStderr, pattern.substring) {
+			return pattern.class
+		}
+	}
+
+	return nil
+}
+
+func tailLines(output string, maxLines int) string {
+	lines := strings.Split(output, "\n")
+
+	if len(lines) <= maxLines {
+		return output
+	}
+
+	return strings.Join(lines[len(lines)-maxLines:], "\n")
+}
@@ -0,0 +1,214 @@
+package env
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestClassifyGitStderr(t *testing.T) {
+	testCases := []struct {
+		name          string
+		stderr        string
+		expectedClass error
+	}{
+		{
+			name: "host key changed",
+			stderr: "@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@\n" +
+				"@    WARNING: REMOTE HOST IDENTIFICATION HAS CHANGED!     @\n" +
+				"@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@\n" +
+				"Host key verification failed.\n" +
+				"fatal: Could not read from remote repository.",
+			expectedClass: ErrGitHostKeyMismatch,
+		},
+		{
+			name: "host key unknown",
+			stderr: "No ED25519 host key is known for github.com and you have requested strict checking.\n" +
+				"Host key verification failed.\n" +
+				"fatal: Could not read from remote repository.",
+			expectedClass: ErrGitHostKeyUnknown,
+		},
+		{
+			name:          "host key verification failed",
+			stderr:        "Host key verification failed.\nfatal: Could not read from remote repository.",
+			expectedClass: ErrGitHostKeyMismatch,
+		},
+		{
+			name:          "ssh key rejected",
+			stderr:        "git@github.com: Permission denied (publickey).\nfatal: Could not read from remote repository.",
+			expectedClass: ErrGitAuthFailed,
+		},
+		{
+			name:          "https token rejected",
+			stderr:        "remote: Invalid username or password.\nfatal: Authentication failed for 'https://github.com/yolo-sh/agent-container.git/'",
+			expectedClass: ErrGitAuthFailed,
+		},
+		{
+			name:          "https no credentials",
+			stderr:        "fatal: could not read Username for 'https://github.com': terminal prompts disabled",
+			expectedClass: ErrGitAuthFailed,
+		},
+		{
+			name:          "https forbidden",
+			stderr:        "fatal: unable to access 'https://github.com/yolo-sh/agent-container.git/': The requested URL returned error: 403",
+			expectedClass: ErrGitAuthFailed,
+		},
+		{
+			name:          "repository not found",
+			stderr:        "ERROR: Repository not found.\nfatal: Could not read from remote repository.",
+			expectedClass: ErrGitRepoNotFound,
+		},
+		{
+			name:          "not a repository",
+			stderr:        "fatal: 'yolo-sh/unknown.git' does not appear to be a git repository",
+			expectedClass: ErrGitRepoNotFound,
+		},
+		{
+			name:          "dns failure over ssh",
+			stderr:        "ssh: Could not resolve hostname github.com: Temporary failure in name resolution",
+			expectedClass: ErrGitNetworkUnreachable,
+		},
+		{
+			name:          "dns failure over https",
+			stderr:        "fatal: unable to access 'https://github.com/yolo-sh/agent-container.git/': Could not resolve host: github.com",
+			expectedClass: ErrGitNetworkUnreachable,
+		},
+		{
+			name:          "connection timed out",
+			stderr:        "ssh: connect to host github.com port 22: Connection timed out",
+			expectedClass: ErrGitNetworkUnreachable,
+		},
+		{
+			name:          "unknown",
+			stderr:        "fatal: the remote end hung up unexpectedly",
+			expectedClass: nil,
+		},
+		{
+			name:          "empty",
+			stderr:        "",
+			expectedClass: nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if class := classifyGitStderr(tc.stderr); class != tc.expectedClass {
+				t.Fatalf("expected class %v, got %v", tc.expectedClass, class)
+			}
+		})
+	}
+}
+
+func TestTailLines(t *testing.T) {
+	testCases := []struct {
+		name           string
+		output         string
+		maxLines       int
+		expectedOutput string
+	}{
+		{
+			name:           "fewer lines",
+			output:         "a\nb",
+			maxLines:       3,
+			expectedOutput: "a\nb",
+		},
+		{
+			name:           "as many lines",
+			output:         "a\nb\nc",
+			maxLines:       3,
+			expectedOutput: "a\nb\nc",
+		},
+		{
+			name:           "more lines",
+			output:         "a\nb\nc\nd",
+			maxLines:       2,
+			expectedOutput: "c\nd",
+		},
+		{
+			name:           "empty",
+			output:         "",
+			maxLines:       2,
+			expectedOutput: "",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if output := tailLines(tc.output, tc.maxLines); output != tc.expectedOutput {
+				t.Fatalf("expected %q, got %q", tc.expectedOutput, output)
+			}
+		})
+	}
+}
+
+func TestNewGitError(t *testing.T) {
+	cmdErr := errors.New("exit status 128")
+
+	stderrLines := []string{}
+
+	for i := 0; i < gitStderrTailMaxLines+5; i++ {
+		stderrLines = append(stderrLines, "remote: line "+strconv.Itoa(i))
+	}
+
+	stderrLines = append(stderrLines, "ERROR: Repository not found.")
+
+	gitErr := newGitError(
+		"clone",
+		GitTransportSSH,
+		"yolo-sh",
+		"agent-container",
+		"\n"+strings.Join(stderrLines, "\n")+"\n\n",
+		cmdErr,
+	)
+
+	if !errors.Is(gitErr, ErrGitRepoNotFound) {
+		t.Fatalf("expected the error to match %v", ErrGitRepoNotFound)
+	}
+
+	if !errors.Is(gitErr, cmdErr) {
+		t.Fatal("expected the error to wrap the command error")
+	}
+
+	stderrTailLines := strings.Split(gitErr.StderrTail, "\n")
+
+	if len(stderrTailLines) != gitStderrTailMaxLines {
+		t.Fatalf("expected %d stderr lines, got %d", gitStderrTailMaxLines, len(stderrTailLines))
+	}
+
+	if stderrTailLines[len(stderrTailLines)-1] != "ERROR: Repository not found." {
+		t.Fatalf("unexpected last stderr line %q", stderrTailLines[len(stderrTailLines)-1])
+	}
+
+	// The stderr lines are joined
+	errMessage := gitErr.Error()
+
+	if !strings.HasPrefix(errMessage, "error while running \"git clone\" on the repository \"yolo-sh/agent-container\".\n\n") {
+		t.Fatalf("unexpected error message %q", errMessage)
+	}
+
+	if strings.Count(errMessage, "\n") != 4 {
+		t.Fatalf("expected the stderr on a single line, got %q", errMessage)
+	}
+}
+
+func TestGitErrorUnwrapWithoutClass(t *testing.T) {
+	cmdErr := errors.New("exit status 128")
+
+	gitErr := newGitError(
+		"ls-remote",
+		GitTransportHTTPS,
+		"yolo-sh",
+		"agent-container",
+		"fatal: the remote end hung up unexpectedly",
+		cmdErr,
+	)
+
+	if gitErr.Class != nil {
+		t.Fatalf("expected no class, got %v", gitErr.Class)
+	}
+
+	if !errors.Is(gitErr, cmdErr) {
+		t.Fatal("expected the error to wrap the command error")
+	}
+}
@@ -0,0 +1,231 @@
+package env
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/yolo-sh/agent-container/internal/metrics"
+)
+
+// GitAccessCheckPolicy configures the retries
+// of "CheckGitHubRepoAccess".
+type GitAccessCheckPolicy struct {
+	MaxAttempts int
+	// Doubled after each retry
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+}
+
+func NewDefaultGitAccessCheckPolicy() GitAccessCheckPolicy {
+	return GitAccessCheckPolicy{
+		MaxAttempts:    6,
+		InitialBackoff: 2 * time.Second,
+		MaxBackoff:     15 * time.Second,
+	}
+}
+
+// ProgressReporter receives the progress
+// of the long-running workspace operations.
+type ProgressReporter interface {
+	ReportHeader(header string)
+	ReportLine(line string)
+}
+
+// CheckGitHubRepoAccess runs "git ls-remote" on the repository
+// with the SSH key generated in the environment (or the HTTPS
+// credentials), before cloning it. Only the network errors and,
+// over SSH, the authentication errors are retried given that
+// GitHub may take a few seconds to accept a newly added SSH key.
+// The returned errors are "*GitError" with a precise diagnosis.
+func CheckGitHubRepoAccess(
+	ctx context.Context,
+	repoOwner string,
+	repoName string,
+	gitAuth GitAuth,
+	policy GitAccessCheckPolicy,
+	progress ProgressReporter,
+) error {
+
+	progress.ReportHeader(
+		fmt.Sprintf("Checking access to %s/%s", repoOwner, repoName),
+	)
+
+	maxAttempts := policy.MaxAttempts
+
+	if maxAttempts < 1 {
+		maxAttempts = 1
+	}
+
+	for attempt := 1; ; attempt++ {
+		err := lsRemoteGitHubRepo(ctx, repoOwner, repoName, gitAuth)
+
+		if err != nil && ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		metrics.GitAccessChecks.WithLabelValues(
+			buildGitAccessCheckResult(err),
+		).Inc()
+
+		if err == nil {
+			progress.ReportLine("Repository access checked")
+			return nil
+		}
+
+		logger.WarnContext(
+			ctx,
+			"error when checking repository access",
+			"repo_owner", repoOwner,
+			"repo_name", repoName,
+			"attempt", attempt,
+			"max_attempts", maxAttempts,
+			"error", err,
+		)
+
+		diagnosis := diagnoseGitAccessErr(err, gitAuth)
+
+		if !isGitAccessErrRetryable(err, gitAuth) || attempt >= maxAttempts {
+			progress.ReportLine(diagnosis)
+			return err
+		}
+
+		backoff := computeGitAccessCheckBackoff(attempt, policy)
+
+		progress.ReportLine(
+			fmt.Sprintf(
+				"%s Retrying in %s (attempt %d/%d).",
+				diagnosis,
+				backoff,
+				attempt+1,
+				maxAttempts,
+			),
+		)
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+	}
+}
+
+// The backoff after the failed attempt "attempt" (starting at 1)
+func computeGitAccessCheckBackoff(
+	attempt int,
+	policy GitAccessCheckPolicy,
+) time.Duration {
+
+	backoff := policy.InitialBackoff
+
+	for i := 1; i < attempt; i++ {
+		if policy.MaxBackoff > 0 && backoff >= policy.MaxBackoff {
+			break
+		}
+
+		backoff *= 2
+	}
+
+	if policy.MaxBackoff > 0 && backoff > policy.MaxBackoff {
+		return policy.MaxBackoff
+	}
+
+	return backoff
+}
+
+func lsRemoteGitHubRepo(
+	ctx context.Context,
+	repoOwner string,
+	repoName string,
+	gitAuth GitAuth,
+) error {
+
+	cmd := buildGitCmd(
+		ctx,
+		gitAuth,
+		"ls-remote",
+		"--quiet",
+		buildGitHubRepoURL(repoOwner, repoName, gitAuth),
+		"HEAD",
+	)
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return newGitError(
+			"ls-remote",
+			gitAuth.Transport(),
+			repoOwner,
+			repoName,
+			stderr.String(),
+			err,
+		)
+	}
+
+	return nil
+}
+
+func isGitAccessErrRetryable(err error, gitAuth GitAuth) bool {
+	if errors.Is(err, ErrGitNetworkUnreachable) {
+		return true
+	}
+
+	// Unlike the generated key, a rejected token
+	// (or forwarded key) will not be accepted later
+	return errors.Is(err, ErrGitAuthFailed) &&
+		gitAuth.Transport() == GitTransportSSH
+}
+
+func buildGitAccessCheckResult(err error) string {
+	switch {
+	case err == nil:
+		return metrics.GitAccessCheckResultOK
+	case errors.Is(err, ErrGitHostKeyMismatch):
+		return metrics.GitAccessCheckResultHostKeyMismatch
+	case errors.Is(err, ErrGitHostKeyUnknown):
+		return metrics.GitAccessCheckResultHostKeyUnknown
+	case errors.Is(err, ErrGitAuthFailed):
+		return metrics.GitAccessCheckResultAuthFailed
+	case errors.Is(err, ErrGitRepoNotFound):
+		return metrics.GitAccessCheckResultRepoNotFound
+	case errors.Is(err, ErrGitNetworkUnreachable):
+		return metrics.GitAccessCheckResultUnreachable
+	}
+
+	return metrics.GitAccessCheckResultUnknown
+}
+
+func diagnoseGitAccessErr(err error, gitAuth GitAuth) string {
+	switch {
+	case errors.Is(err, ErrGitHostKeyMismatch):
+		return "The host key of github.com doesn't match the one in ~/.ssh/known_hosts. " +
+			"The connection may be intercepted or the GitHub host keys may have changed."
+	case errors.Is(err, ErrGitHostKeyUnknown):
+		return "The host key of github.com is not in ~/.ssh/known_hosts " +
+			"(it may not have been added by the init script)."
+	case errors.Is(err, ErrGitAuthFailed) && gitAuth.Transport() == GitTransportSSHAgent:
+		return "None of the keys of the SSH agent forwarded from the host is registered in your GitHub account."
+	case errors.Is(err, ErrGitAuthFailed) && gitAuth.Transport() == GitTransportHTTPS:
+		return "The HTTPS token was rejected by GitHub (it may have expired)."
+	case errors.Is(err, ErrGitAuthFailed):
+		return "The SSH key generated in the environment is not registered in your GitHub account yet " +
+			"(or GitHub doesn't accept it yet)."
+	case errors.Is(err, ErrGitRepoNotFound):
+		return "The repository doesn't exist or your GitHub account doesn't have access to it."
+	case errors.Is(err, ErrGitNetworkUnreachable):
+		return "GitHub could not be reached from the environment."
+	}
+
+	var gitErr *GitError
+
+	if errors.As(err, &gitErr) && len(gitErr.StderrTail) > 0 {
+		lines := strings.Split(gitErr.StderrTail, "\n")
+		return fmt.Sprintf("The repository could not be accessed: %s", lines[len(lines)-1])
+	}
+
+	return fmt.Sprintf("The repository could not be accessed: %v", err)
+}
@@ -0,0 +1,202 @@
+package env
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/yolo-sh/agent-container/internal/metrics"
+)
+
+func TestComputeGitAccessCheckBackoff(t *testing.T) {
+	policy := GitAccessCheckPolicy{
+		MaxAttempts:    10,
+		InitialBackoff: 2 * time.Second,
+		MaxBackoff:     15 * time.Second,
+	}
+
+	testCases := []struct {
+		name            string
+		attempt         int
+		policy          GitAccessCheckPolicy
+		expectedBackoff time.Duration
+	}{
+		{
+			name:            "first attempt",
+			attempt:         1,
+			policy:          policy,
+			expectedBackoff: 2 * time.Second,
+		},
+		{
+			name:            "doubled",
+			attempt:         3,
+			policy:          policy,
+			expectedBackoff: 8 * time.Second,
+		},
+		{
+			name:            "capped",
+			attempt:         4,
+			policy:          policy,
+			expectedBackoff: 15 * time.Second,
+		},
+		{
+			name:            "still capped",
+			attempt:         100,
+			policy:          policy,
+			expectedBackoff: 15 * time.Second,
+		},
+		{
+			name:    "not capped",
+			attempt: 5,
+			policy: GitAccessCheckPolicy{
+				InitialBackoff: time.Second,
+			},
+			expectedBackoff: 16 * time.Second,
+		},
+		{
+			name:    "initial backoff above max",
+			attempt: 1,
+			policy: GitAccessCheckPolicy{
+				InitialBackoff: time.Minute,
+				MaxBackoff:     15 * time.Second,
+			},
+			expectedBackoff: 15 * time.Second,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			backoff := computeGitAccessCheckBackoff(tc.attempt, tc.policy)
+
+			if backoff != tc.expectedBackoff {
+				t.Fatalf("expected %s, got %s", tc.expectedBackoff, backoff)
+			}
+		})
+	}
+}
+
+func TestGitAccessCheckErrors(t *testing.T) {
+	sshAuth := GitAuth{}
+	sshAgentAuth := GitAuth{SSHAgentSocketPath: "/run/yolo-ssh-agent/agent.sock"}
+	httpsAuth := GitAuth{HTTPSCredentialHelper: "/usr/local/bin/git-credential-yolo"}
+
+	testCases := []struct {
+		name              string
+		err               error
+		gitAuth           GitAuth
+		expectedRetryable bool
+		expectedResult    string
+	}{
+		{
+			name:              "network over https",
+			err:               newTestGitError("Could not resolve host: github.com"),
+			gitAuth:           httpsAuth,
+			expectedRetryable: true,
+			expectedResult:    metrics.GitAccessCheckResultUnreachable,
+		},
+		{
+			name:              "auth over ssh",
+			err:               newTestGitError("Permission denied (publickey)."),
+			gitAuth:           sshAuth,
+			expectedRetryable: true,
+			expectedResult:    metrics.GitAccessCheckResultAuthFailed,
+		},
+		{
+			name:              "auth over forwarded ssh agent",
+			err:               newTestGitError("Permission denied (publickey)."),
+			gitAuth:           sshAgentAuth,
+			expectedRetryable: false,
+			expectedResult:    metrics.GitAccessCheckResultAuthFailed,
+		},
+		{
+			name:              "auth over https",
+			err:               newTestGitError("fatal: Authentication failed for 'https://github.com/'"),
+			gitAuth:           httpsAuth,
+			expectedRetryable: false,
+			expectedResult:    metrics.GitAccessCheckResultAuthFailed,
+		},
+		{
+			name:              "host key unknown",
+			err:               newTestGitError("No ED25519 host key is known for github.com"),
+			gitAuth:           sshAuth,
+			expectedRetryable: false,
+			expectedResult:    metrics.GitAccessCheckResultHostKeyUnknown,
+		},
+		{
+			name:              "host key mismatch",
+			err:               newTestGitError("Host key verification failed."),
+			gitAuth:           sshAuth,
+			expectedRetryable: false,
+			expectedResult:    metrics.GitAccessCheckResultHostKeyMismatch,
+		},
+		{
+			name:              "repository not found",
+			err:               newTestGitError("ERROR: Repository not found."),
+			gitAuth:           sshAuth,
+			expectedRetryable: false,
+			expectedResult:    metrics.GitAccessCheckResultRepoNotFound,
+		},
+		{
+			name:              "unknown",
+			err:               errors.New("exec: \"git\": executable file not found in $PATH"),
+			gitAuth:           sshAuth,
+			expectedRetryable: false,
+			expectedResult:    metrics.GitAccessCheckResultUnknown,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if retryable := isGitAccessErrRetryable(tc.err, tc.gitAuth); retryable != tc.expectedRetryable {
+				t.Fatalf("expected retryable %t, got %t", tc.expectedRetryable, retryable)
+			}
+
+			if result := buildGitAccessCheckResult(tc.err); result != tc.expectedResult {
+				t.Fatalf("expected result %q, got %q", tc.expectedResult, result)
+			}
+		})
+	}
+}
+
+func TestDiagnoseGitAccessErr(t *testing.T) {
+	testCases := []struct {
+		name              string
+		err               error
+		expectedDiagnosis string
+	}{
+		{
+			name:              "known class",
+			err:               newTestGitError("ERROR: Repository not found."),
+			expectedDiagnosis: "The repository doesn't exist or your GitHub account doesn't have access to it.",
+		},
+		{
+			name:              "last stderr line",
+			err:               newTestGitError("remote: counting objects\nfatal: the remote end hung up unexpectedly"),
+			expectedDiagnosis: "The repository could not be accessed: fatal: the remote end hung up unexpectedly",
+		},
+		{
+			name:              "not a git error",
+			err:               errors.New("signal: killed"),
+			expectedDiagnosis: "The repository could not be accessed: signal: killed",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if diagnosis := diagnoseGitAccessErr(tc.err, GitAuth{}); diagnosis != tc.expectedDiagnosis {
+				t.Fatalf("expected %q, got %q", tc.expectedDiagnosis, diagnosis)
+			}
+		})
+	}
+}
+
+func newTestGitError(stderr string) error {
+	return newGitError(
+		"ls-remote",
+		GitTransportSSH,
+		"yolo-sh",
+		"agent-container",
+		stderr,
+		errors.New("exit status 128"),
+	)
+}
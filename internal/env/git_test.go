@@ -0,0 +1,134 @@
+package env
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestGitAuthTransport(t *testing.T) {
+	testCases := []struct {
+		name              string
+		gitAuth           GitAuth
+		expectedTransport string
+		expectedRepoURL   string
+	}{
+		{
+			name:              "generated SSH key",
+			gitAuth:           GitAuth{},
+			expectedTransport: GitTransportSSH,
+			expectedRepoURL:   "git@github.com:yolo-sh/agent-container.git",
+		},
+		{
+			name: "forwarded SSH agent",
+			gitAuth: GitAuth{
+				SSHAgentSocketPath: "/run/yolo-ssh-agent/agent.sock",
+			},
+			expectedTransport: GitTransportSSHAgent,
+			expectedRepoURL:   "git@github.com:yolo-sh/agent-container.git",
+		},
+		{
+			name: "HTTPS",
+			gitAuth: GitAuth{
+				HTTPSCredentialHelper: "/usr/local/bin/git-credential-yolo",
+			},
+			expectedTransport: GitTransportHTTPS,
+			expectedRepoURL:   "https://github.com/yolo-sh/agent-container.git",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if transport := tc.gitAuth.Transport(); transport != tc.expectedTransport {
+				t.Fatalf("expected %q, got %q", tc.expectedTransport, transport)
+			}
+
+			repoURL := buildGitHubRepoURL("yolo-sh", "agent-container", tc.gitAuth)
+
+			if repoURL != tc.expectedRepoURL {
+				t.Fatalf("expected %q, got %q", tc.expectedRepoURL, repoURL)
+			}
+		})
+	}
+}
+
+func TestBuildGitCmd(t *testing.T) {
+	testCases := []struct {
+		name            string
+		gitAuth         GitAuth
+		expectedArgs    []string
+		expectedEnvVars []string
+	}{
+		{
+			name:    "generated SSH key",
+			gitAuth: GitAuth{},
+			expectedArgs: []string{
+				"git", "ls-remote", "git@github.com:yolo-sh/agent-container.git",
+			},
+			expectedEnvVars: []string{
+				"GIT_TERMINAL_PROMPT=0",
+				"GIT_SSH_COMMAND=ssh -o BatchMode=yes -o ConnectTimeout=10",
+			},
+		},
+		{
+			name: "forwarded SSH agent",
+			gitAuth: GitAuth{
+				SSHAgentSocketPath: "/run/yolo-ssh-agent/agent.sock",
+			},
+			expectedArgs: []string{
+				"git", "ls-remote", "git@github.com:yolo-sh/agent-container.git",
+			},
+			expectedEnvVars: []string{
+				"GIT_TERMINAL_PROMPT=0",
+				"SSH_AUTH_SOCK=/run/yolo-ssh-agent/agent.sock",
+			},
+		},
+		{
+			name: "HTTPS",
+			gitAuth: GitAuth{
+				HTTPSCredentialHelper: "/usr/local/bin/git-credential-yolo",
+			},
+			expectedArgs: []string{
+				"git",
+				"-c", "credential.helper=",
+				"-c", "credential.helper=/usr/local/bin/git-credential-yolo",
+				"ls-remote", "git@github.com:yolo-sh/agent-container.git",
+			},
+			expectedEnvVars: []string{
+				"GIT_TERMINAL_PROMPT=0",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cmd := buildGitCmd(
+				context.Background(),
+				tc.gitAuth,
+				"ls-remote",
+				"git@github.com:yolo-sh/agent-container.git",
+			)
+
+			if !reflect.DeepEqual(cmd.Args, tc.expectedArgs) {
+				t.Fatalf("expected the args %q, got %q", tc.expectedArgs, cmd.Args)
+			}
+
+			// The last value wins
+			envVars := map[string]string{}
+
+			for _, envVar := range cmd.Env {
+				key, value, _ := strings.Cut(envVar, "=")
+				envVars[key] = value
+			}
+
+			for _, expectedEnvVar := range tc.expectedEnvVars {
+				key, value, _ := strings.Cut(expectedEnvVar, "=")
+
+				if envVars[key] != value {
+					t.Fatalf("expected %s=%q, got %q", key, value, envVars[key])
+				}
+			}
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package env
 
 import (
+	"context"
 	"path/filepath"
 
 	"github.com/yolo-sh/agent-container/constants"
@@ -9,9 +10,11 @@ import (
 )
 
 func PrepareWorkspace(
+	ctx context.Context,
 	workspaceConfig *entities.WorkspaceConfig,
 	repoOwner string,
 	repoName string,
+	gitAuth GitAuth,
 	languagesUsedInRepo []string,
 ) error {
 	vscodeWorkspaceConfig := buildInitialVSCodeWorkspaceConfig(languagesUsedInRepo)
@@ -28,8 +31,10 @@ func PrepareWorkspace(
 	}
 
 	err = addRepoToWorkspace(
+		ctx,
 		repoOwner,
 		repoName,
+		gitAuth,
 		workspaceConfig,
 		&vscodeWorkspaceConfig,
 	)
@@ -54,8 +59,10 @@ func PrepareWorkspace(
 }
 
 func addRepoToWorkspace(
+	ctx context.Context,
 	repoOwner string,
 	repoName string,
+	gitAuth GitAuth,
 	workspaceConfig *entities.WorkspaceConfig,
 	vscodeWorkspaceConfig *VSCodeWorkspaceConfig,
 ) error {
@@ -66,8 +73,10 @@ func addRepoToWorkspace(
 	)
 
 	err := cloneGitHubRepo(
+		ctx,
 		repoOwner,
 		repoName,
+		gitAuth,
 		repoDirPathInWorkspace,
 	)
 
@@ -0,0 +1,113 @@
+package env
+
+import (
+	"context"
+	"errors"
+	"os"
+	"syscall"
+	"time"
+
+	"github.com/yolo-sh/agent-container/constants"
+)
+
+var ErrWorkspaceLocked = errors.New("a workspace operation is already running")
+
+// The interval between two attempts to
+// lock the file locked by another process
+const workspaceFileLockPollInterval = 100 * time.Millisecond
+
+// Serializes the operations that modify the workspace
+// (eg: "Init" runs) in this process. The file lock
+// serializes them across processes.
+var workspaceLock = make(chan struct{}, 1)
+
+// LockWorkspace waits until the workspace could be locked
+// or the context is done. The returned function unlocks it.
+func LockWorkspace(ctx context.Context) (func(), error) {
+	select {
+	case workspaceLock <- struct{}{}:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	for {
+		unlockFile, err := tryLockWorkspaceFile(constants.WorkspaceLockFilePath)
+
+		if err == nil {
+			return func() {
+				unlockFile()
+				<-workspaceLock
+			}, nil
+		}
+
+		if !errors.Is(err, ErrWorkspaceLocked) {
+			<-workspaceLock
+			return nil, err
+		}
+
+		select {
+		case <-ctx.Done():
+			<-workspaceLock
+			return nil, ctx.Err()
+		case <-time.After(workspaceFileLockPollInterval):
+		}
+	}
+}
+
+// TryLockWorkspace returns "ErrWorkspaceLocked"
+// if the workspace is already locked.
+func TryLockWorkspace() (func(), error) {
+	select {
+	case workspaceLock <- struct{}{}:
+	default:
+		return nil, ErrWorkspaceLocked
+	}
+
+	unlockFile, err := tryLockWorkspaceFile(constants.WorkspaceLockFilePath)
+
+	if err != nil {
+		<-workspaceLock
+		return nil, err
+	}
+
+	return func() {
+		unlockFile()
+		<-workspaceLock
+	}, nil
+}
+
+func tryLockWorkspaceFile(lockFilePath string) (func(), error) {
+	lockFile, err := os.OpenFile(
+		lockFilePath,
+		os.O_CREATE|os.O_RDWR,
+		0660,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	// Overwrite umask.
+	// See: https://stackoverflow.com/questions/50257981/ioutils-writefile-not-respecting-permissions
+	if err := lockFile.Chmod(0660); err != nil {
+		lockFile.Close()
+		return nil, err
+	}
+
+	err = syscall.Flock(int(lockFile.Fd()), syscall.LOCK_EX|syscall.LOCK_NB)
+
+	if errors.Is(err, syscall.EWOULDBLOCK) {
+		lockFile.Close()
+		return nil, ErrWorkspaceLocked
+	}
+
+	if err != nil {
+		lockFile.Close()
+		return nil, err
+	}
+
+	return func() {
+		// Closing the file releases the lock
+		lockFile.Close()
+	}, nil
+}
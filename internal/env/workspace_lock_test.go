@@ -0,0 +1,56 @@
+package env
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestTryLockWorkspaceFile(t *testing.T) {
+	lockFilePath := filepath.Join(t.TempDir(), "workspace.lock")
+
+	unlockFile, err := tryLockWorkspaceFile(lockFilePath)
+
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	// The lock is held by the open file description
+	// so it also conflicts in the same process
+	_, err = tryLockWorkspaceFile(lockFilePath)
+
+	if !errors.Is(err, ErrWorkspaceLocked) {
+		t.Fatalf("expected %v, got %v", ErrWorkspaceLocked, err)
+	}
+
+	unlockFile()
+
+	unlockFile, err = tryLockWorkspaceFile(lockFilePath)
+
+	if err != nil {
+		t.Fatalf("expected the lock to be released, got %v", err)
+	}
+
+	unlockFile()
+
+	lockFileInfo, err := os.Stat(lockFilePath)
+
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	if lockFileInfo.Mode().Perm() != 0660 {
+		t.Fatalf("expected the mode 0660, got %o", lockFileInfo.Mode().Perm())
+	}
+}
+
+func TestTryLockWorkspaceFileMissingDir(t *testing.T) {
+	lockFilePath := filepath.Join(t.TempDir(), "unknown", "workspace.lock")
+
+	_, err := tryLockWorkspaceFile(lockFilePath)
+
+	if err == nil || errors.Is(err, ErrWorkspaceLocked) {
+		t.Fatalf("expected an error other than %v, got %v", ErrWorkspaceLocked, err)
+	}
+}
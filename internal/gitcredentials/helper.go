@@ -0,0 +1,88 @@
+package gitcredentials
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"net"
+	"os"
+	"time"
+)
+
+// HelperName is the name the agent binary is run as when
+// used as a git credential helper (see "InstallHelper").
+const HelperName = "git-credential-yolo"
+
+// InstallHelper links the helper path to the agent binary
+// so that git could run it with the operation as argument.
+func InstallHelper(helperPath string) error {
+	agentBinaryPath, err := os.Executable()
+
+	if err != nil {
+		return err
+	}
+
+	err = os.Remove(helperPath)
+
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	return os.Symlink(agentBinaryPath, helperPath)
+}
+
+// RunHelper forwards the request read from "stdin" to the
+// agent listening on the socket and writes the credentials
+// to "stdout" (see "git help credential"). The credentials
+// are only returned for the "get" operation.
+func RunHelper(
+	socketPath string,
+	operation string,
+	stdin io.Reader,
+	stdout io.Writer,
+) error {
+
+	switch operation {
+	case "get", "store", "erase":
+	default:
+		// Unknown operations must be ignored
+		return nil
+	}
+
+	req, err := readRequest(bufio.NewReader(stdin))
+
+	if err != nil {
+		return err
+	}
+
+	conn, err := net.DialTimeout("unix", socketPath, connTimeout)
+
+	if err != nil {
+		return err
+	}
+
+	defer conn.Close()
+
+	conn.SetDeadline(time.Now().Add(connTimeout))
+
+	if _, err := io.WriteString(conn, operation+"\n"); err != nil {
+		return err
+	}
+
+	if err := writeRequest(conn, req); err != nil {
+		return err
+	}
+
+	credentials, err := readCredentials(bufio.NewReader(conn))
+
+	if err != nil {
+		return err
+	}
+
+	if len(credentials.Password) == 0 {
+		// Let git try the next helper
+		return nil
+	}
+
+	return writeCredentials(stdout, credentials)
+}
@@ -0,0 +1,230 @@
+package gitcredentials
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRunHelper(t *testing.T) {
+	githubReq := "protocol=https\nhost=github.com\n\n"
+	gitlabReq := "protocol=https\nhost=gitlab.com\n\n"
+
+	testCases := []struct {
+		name                 string
+		operation            string
+		input                string
+		githubToken          string
+		hostConnected        bool
+		hostCredentials      Credentials
+		expectedOutput       string
+		expectedHostRequests []string
+	}{
+		{
+			name:           "get from the store",
+			operation:      "get",
+			input:          githubReq,
+			githubToken:    "token",
+			hostConnected:  true,
+			expectedOutput: "username=x-access-token\npassword=token\n\n",
+		},
+		{
+			name:                 "get from the host",
+			operation:            "get",
+			input:                gitlabReq,
+			githubToken:          "token",
+			hostConnected:        true,
+			hostCredentials:      Credentials{Username: "user", Password: "secret"},
+			expectedOutput:       "username=user\npassword=secret\n\n",
+			expectedHostRequests: []string{"get gitlab.com"},
+		},
+		{
+			name:                 "get without credentials on the host",
+			operation:            "get",
+			input:                gitlabReq,
+			hostConnected:        true,
+			expectedOutput:       "",
+			expectedHostRequests: []string{"get gitlab.com"},
+		},
+		{
+			name:           "get without host",
+			operation:      "get",
+			input:          gitlabReq,
+			expectedOutput: "",
+		},
+		{
+			name:                 "store is forwarded to the host",
+			operation:            "store",
+			input:                "protocol=https\nhost=gitlab.com\nusername=user\npassword=secret\n\n",
+			hostConnected:        true,
+			expectedOutput:       "",
+			expectedHostRequests: []string{"store gitlab.com"},
+		},
+		{
+			name:           "erase of the store credentials",
+			operation:      "erase",
+			input:          githubReq,
+			githubToken:    "token",
+			hostConnected:  true,
+			expectedOutput: "",
+		},
+		{
+			name:           "unknown operation",
+			operation:      "capability",
+			input:          githubReq,
+			githubToken:    "token",
+			hostConnected:  true,
+			expectedOutput: "",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			store := NewStore()
+			store.SetGitHubToken(tc.githubToken)
+
+			hostBroker := NewHostBroker()
+			hostRequests := make(chan string, 10)
+
+			if tc.hostConnected {
+				session := hostBroker.Connect()
+				defer session.Close()
+
+				go func() {
+					for {
+						select {
+						case hostReq := <-session.Requests():
+							hostRequests <- hostReq.Operation + " " + hostReq.Host
+
+							if hostReq.Operation == "get" {
+								session.Resolve(hostReq.ID, tc.hostCredentials)
+							}
+						case <-session.Done():
+							return
+						}
+					}
+				}()
+			}
+
+			socketPath := serveTestHelperSocket(t, store, hostBroker)
+
+			var output bytes.Buffer
+
+			err := RunHelper(
+				socketPath,
+				tc.operation,
+				strings.NewReader(tc.input),
+				&output,
+			)
+
+			if err != nil {
+				t.Fatalf("unexpected error %v", err)
+			}
+
+			if output.String() != tc.expectedOutput {
+				t.Fatalf("expected %q, got %q", tc.expectedOutput, output.String())
+			}
+
+			for _, expectedHostRequest := range tc.expectedHostRequests {
+				select {
+				case hostRequest := <-hostRequests:
+					if hostRequest != expectedHostRequest {
+						t.Fatalf("expected host request %q, got %q", expectedHostRequest, hostRequest)
+					}
+				case <-time.After(time.Second):
+					t.Fatalf("expected host request %q", expectedHostRequest)
+				}
+			}
+
+			select {
+			case hostRequest := <-hostRequests:
+				t.Fatalf("unexpected host request %q", hostRequest)
+			default:
+			}
+		})
+	}
+}
+
+func TestHostBrokerConnect(t *testing.T) {
+	hostBroker := NewHostBroker()
+
+	firstSession := hostBroker.Connect()
+	secondSession := hostBroker.Connect()
+
+	select {
+	case <-firstSession.Done():
+	default:
+		t.Fatal("expected the previous session to be closed")
+	}
+
+	// Closing a replaced session keeps the current one
+	firstSession.Close()
+
+	go func() {
+		hostReq := <-secondSession.Requests()
+		secondSession.Resolve(hostReq.ID, Credentials{Username: "user", Password: "secret"})
+	}()
+
+	credentials, found := hostBroker.Request(
+		context.Background(),
+		"get",
+		Request{Protocol: "https", Host: "gitlab.com"},
+	)
+
+	if !found || credentials.Password != "secret" {
+		t.Fatalf("expected the credentials of the current session, got %+v", credentials)
+	}
+
+	secondSession.Close()
+
+	if _, found := hostBroker.Request(context.Background(), "get", Request{}); found {
+		t.Fatal("expected no credentials without session")
+	}
+}
+
+// The Unix socket paths are limited to 108 bytes
+// so the temporary directory is not the one of the test
+func serveTestHelperSocket(
+	t *testing.T,
+	store *Store,
+	hostBroker *HostBroker,
+) string {
+
+	t.Helper()
+
+	dirPath, err := os.MkdirTemp("", "git-credentials")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() { os.RemoveAll(dirPath) })
+
+	socketPath := filepath.Join(dirPath, "helper.sock")
+	listener, err := net.Listen("unix", socketPath)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() { listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+
+			if err != nil {
+				return
+			}
+
+			go handleConn(conn, store, hostBroker)
+		}
+	}()
+
+	return socketPath
+}
@@ -0,0 +1,174 @@
+package gitcredentials
+
+import (
+	"context"
+	"sync"
+)
+
+// HostRequest is a request of the credential
+// helper forwarded to the host agent
+type HostRequest struct {
+	ID uint64
+	// "get", "store" or "erase"
+	Operation string
+	Request
+}
+
+// HostBroker forwards the requests of the credential helper
+// to the host agent, via the most recent session
+// (see "CredentialRequests" in the gRPC server)
+// so that the secrets stay on the host.
+type HostBroker struct {
+	mutex         sync.Mutex
+	nextRequestID uint64
+	session       *HostSession
+}
+
+func NewHostBroker() *HostBroker {
+	return &HostBroker{}
+}
+
+// Connect starts a new session. The
+// previous one, if any, is closed.
+func (h *HostBroker) Connect() *HostSession {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	if h.session != nil {
+		h.session.close()
+	}
+
+	h.session = &HostSession{
+		broker:   h,
+		requests: make(chan HostRequest),
+		done:     make(chan struct{}),
+		pending:  map[uint64]chan Credentials{},
+	}
+
+	return h.session
+}
+
+// Request returns false when no host agent is connected or
+// when it has no credentials for the request. The "store" and
+// "erase" operations are sent without waiting for a reply.
+func (h *HostBroker) Request(
+	ctx context.Context,
+	operation string,
+	req Request,
+) (Credentials, bool) {
+
+	h.mutex.Lock()
+
+	session := h.session
+	h.nextRequestID++
+	requestID := h.nextRequestID
+
+	h.mutex.Unlock()
+
+	if session == nil {
+		return Credentials{}, false
+	}
+
+	return session.request(ctx, HostRequest{
+		ID:        requestID,
+		Operation: operation,
+		Request:   req,
+	})
+}
+
+// HostSession is the connection of a host agent
+type HostSession struct {
+	broker   *HostBroker
+	requests chan HostRequest
+
+	closeOnce sync.Once
+	done      chan struct{}
+
+	mutex   sync.Mutex
+	pending map[uint64]chan Credentials
+}
+
+// Requests returns the requests to send to the host agent
+func (h *HostSession) Requests() <-chan HostRequest {
+	return h.requests
+}
+
+// Done is closed once the session is closed
+// (eg: when replaced by a new session)
+func (h *HostSession) Done() <-chan struct{} {
+	return h.done
+}
+
+// Resolve answers a "get" request. The empty
+// credentials mean that the host has none.
+func (h *HostSession) Resolve(requestID uint64, credentials Credentials) {
+	h.mutex.Lock()
+
+	replyChan, ok := h.pending[requestID]
+	delete(h.pending, requestID)
+
+	h.mutex.Unlock()
+
+	if ok {
+		// Buffered
+		replyChan <- credentials
+	}
+}
+
+func (h *HostSession) Close() {
+	h.broker.mutex.Lock()
+	defer h.broker.mutex.Unlock()
+
+	if h.broker.session == h {
+		h.broker.session = nil
+	}
+
+	h.close()
+}
+
+func (h *HostSession) close() {
+	h.closeOnce.Do(func() {
+		close(h.done)
+	})
+}
+
+func (h *HostSession) request(
+	ctx context.Context,
+	hostReq HostRequest,
+) (Credentials, bool) {
+
+	replyChan := make(chan Credentials, 1)
+
+	if hostReq.Operation == "get" {
+		h.mutex.Lock()
+		h.pending[hostReq.ID] = replyChan
+		h.mutex.Unlock()
+
+		defer func() {
+			h.mutex.Lock()
+			delete(h.pending, hostReq.ID)
+			h.mutex.Unlock()
+		}()
+	}
+
+	select {
+	case h.requests <- hostReq:
+	case <-h.done:
+		return Credentials{}, false
+	case <-ctx.Done():
+		return Credentials{}, false
+	}
+
+	if hostReq.Operation != "get" {
+		return Credentials{}, false
+	}
+
+	select {
+	case credentials := <-replyChan:
+		return credentials, len(credentials.Password) > 0
+	case <-h.done:
+		return Credentials{}, false
+	case <-ctx.Done():
+		return Credentials{}, false
+	}
+}
@@ -0,0 +1,142 @@
+package gitcredentials
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Request is the description of the credentials
+// requested by git (see "git help credential").
+type Request struct {
+	Protocol string
+	Host     string
+	Path     string
+	Username string
+	// Only sent for the "store" and "erase" operations
+	Password string
+}
+
+type Credentials struct {
+	Username string
+	Password string
+}
+
+// readRequest reads the "key=value" lines sent by git
+// until an empty line or the end of the input.
+// The unknown attributes are ignored.
+func readRequest(reader *bufio.Reader) (Request, error) {
+	req := Request{}
+
+	for {
+		line, err := reader.ReadString('\n')
+
+		if err != nil && err != io.EOF {
+			return req, err
+		}
+
+		line = strings.TrimRight(line, "\r\n")
+
+		if len(line) == 0 {
+			return req, nil
+		}
+
+		key, value, found := strings.Cut(line, "=")
+
+		if !found {
+			return req, fmt.Errorf("invalid credential attribute \"%s\"", line)
+		}
+
+		switch key {
+		case "protocol":
+			req.Protocol = value
+		case "host":
+			req.Host = value
+		case "path":
+			req.Path = value
+		case "username":
+			req.Username = value
+		case "password":
+			req.Password = value
+		}
+
+		if err == io.EOF {
+			return req, nil
+		}
+	}
+}
+
+func writeRequest(writer io.Writer, req Request) error {
+	return writeAttributes(writer, [][2]string{
+		{"protocol", req.Protocol},
+		{"host", req.Host},
+		{"path", req.Path},
+		{"username", req.Username},
+		{"password", req.Password},
+	})
+}
+
+func readCredentials(reader *bufio.Reader) (Credentials, error) {
+	credentials := Credentials{}
+
+	for {
+		line, err := reader.ReadString('\n')
+
+		if err != nil && err != io.EOF {
+			return credentials, err
+		}
+
+		line = strings.TrimRight(line, "\r\n")
+
+		if len(line) == 0 {
+			return credentials, nil
+		}
+
+		key, value, _ := strings.Cut(line, "=")
+
+		switch key {
+		case "username":
+			credentials.Username = value
+		case "password":
+			credentials.Password = value
+		}
+
+		if err == io.EOF {
+			return credentials, nil
+		}
+	}
+}
+
+// An empty reply lets git try the next helper
+func writeCredentials(writer io.Writer, credentials Credentials) error {
+	return writeAttributes(writer, [][2]string{
+		{"username", credentials.Username},
+		{"password", credentials.Password},
+	})
+}
+
+// The empty values are skipped and
+// the attributes end with an empty line
+func writeAttributes(writer io.Writer, attributes [][2]string) error {
+	var builder strings.Builder
+
+	for _, attribute := range attributes {
+		if len(attribute[1]) == 0 {
+			continue
+		}
+
+		// The values could not contain new lines
+		if strings.ContainsAny(attribute[1], "\n\x00") {
+			return fmt.Errorf("invalid value for credential attribute \"%s\"", attribute[0])
+		}
+
+		builder.WriteString(attribute[0] + "=" + attribute[1] + "\n")
+	}
+
+	builder.WriteString("\n")
+
+	_, err := io.WriteString(writer, builder.String())
+
+	return err
+}
@@ -0,0 +1,225 @@
+package gitcredentials
+
+import (
+	"bufio"
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestReadRequest(t *testing.T) {
+	testCases := []struct {
+		name          string
+		input         string
+		expectedReq   Request
+		expectedError bool
+	}{
+		{
+			name:  "ends with an empty line",
+			input: "protocol=https\nhost=github.com\npath=yolo-sh/agent-container.git\n\nignored=true\n",
+			expectedReq: Request{
+				Protocol: "https",
+				Host:     "github.com",
+				Path:     "yolo-sh/agent-container.git",
+			},
+		},
+		{
+			name:  "ends with the input",
+			input: "protocol=https\nhost=github.com\nusername=x-access-token\npassword=token",
+			expectedReq: Request{
+				Protocol: "https",
+				Host:     "github.com",
+				Username: "x-access-token",
+				Password: "token",
+			},
+		},
+		{
+			name:  "CRLF",
+			input: "protocol=https\r\nhost=github.com\r\n\r\n",
+			expectedReq: Request{
+				Protocol: "https",
+				Host:     "github.com",
+			},
+		},
+		{
+			name:  "unknown attributes",
+			input: "protocol=https\ncapability[]=authtype\nwwwauth[]=Basic realm=\"GitHub\"\n\n",
+			expectedReq: Request{
+				Protocol: "https",
+			},
+		},
+		{
+			name:  "value with equal sign",
+			input: "password=a=b\n\n",
+			expectedReq: Request{
+				Password: "a=b",
+			},
+		},
+		{
+			name:        "empty",
+			input:       "",
+			expectedReq: Request{},
+		},
+		{
+			name:          "invalid attribute",
+			input:         "protocol\n\n",
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req, err := readRequest(bufio.NewReader(strings.NewReader(tc.input)))
+
+			if tc.expectedError {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error %v", err)
+			}
+
+			if req != tc.expectedReq {
+				t.Fatalf("expected %+v, got %+v", tc.expectedReq, req)
+			}
+		})
+	}
+}
+
+func TestWriteRequest(t *testing.T) {
+	testCases := []struct {
+		name           string
+		req            Request
+		expectedOutput string
+		expectedError  bool
+	}{
+		{
+			name: "skips the empty values",
+			req: Request{
+				Protocol: "https",
+				Host:     "github.com",
+			},
+			expectedOutput: "protocol=https\nhost=github.com\n\n",
+		},
+		{
+			name: "all the values",
+			req: Request{
+				Protocol: "https",
+				Host:     "github.com",
+				Path:     "yolo-sh/agent-container.git",
+				Username: "x-access-token",
+				Password: "token",
+			},
+			expectedOutput: "protocol=https\nhost=github.com\npath=yolo-sh/agent-container.git\n" +
+				"username=x-access-token\npassword=token\n\n",
+		},
+		{
+			name:           "empty",
+			req:            Request{},
+			expectedOutput: "\n",
+		},
+		{
+			name: "new line in value",
+			req: Request{
+				Host: "github.com\nusername=attacker",
+			},
+			expectedError: true,
+		},
+		{
+			name: "NUL in value",
+			req: Request{
+				Host: "github.com\x00",
+			},
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var output bytes.Buffer
+
+			err := writeRequest(&output, tc.req)
+
+			if tc.expectedError {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+
+				if output.Len() > 0 {
+					t.Fatalf("expected nothing written, got %q", output.String())
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error %v", err)
+			}
+
+			if output.String() != tc.expectedOutput {
+				t.Fatalf("expected %q, got %q", tc.expectedOutput, output.String())
+			}
+
+			// Round trip
+			req, err := readRequest(bufio.NewReader(&output))
+
+			if err != nil {
+				t.Fatalf("unexpected error %v", err)
+			}
+
+			if req != tc.req {
+				t.Fatalf("expected %+v once read, got %+v", tc.req, req)
+			}
+		})
+	}
+}
+
+func TestCredentialsRoundTrip(t *testing.T) {
+	testCases := []struct {
+		name           string
+		credentials    Credentials
+		expectedOutput string
+	}{
+		{
+			name: "username and password",
+			credentials: Credentials{
+				Username: "x-access-token",
+				Password: "token",
+			},
+			expectedOutput: "username=x-access-token\npassword=token\n\n",
+		},
+		{
+			name:           "no credentials",
+			credentials:    Credentials{},
+			expectedOutput: "\n",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var output bytes.Buffer
+
+			if err := writeCredentials(&output, tc.credentials); err != nil {
+				t.Fatalf("unexpected error %v", err)
+			}
+
+			if output.String() != tc.expectedOutput {
+				t.Fatalf("expected %q, got %q", tc.expectedOutput, output.String())
+			}
+
+			credentials, err := readCredentials(bufio.NewReader(&output))
+
+			if err != nil {
+				t.Fatalf("unexpected error %v", err)
+			}
+
+			if credentials != tc.credentials {
+				t.Fatalf("expected %+v once read, got %+v", tc.credentials, credentials)
+			}
+		})
+	}
+}
@@ -0,0 +1,148 @@
+package gitcredentials
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/yolo-sh/agent-container/internal/logging"
+	"github.com/yolo-sh/agent-container/internal/system"
+)
+
+// How long the host agent could take to return
+// the credentials (eg: when the user is prompted)
+const hostRequestTimeout = time.Minute
+
+// How long a helper connection could stay open
+const connTimeout = hostRequestTimeout + 10*time.Second
+
+var logger = logging.Component(logging.ComponentGitCredentials)
+
+// ListenAndServe serves the credentials of the store or, when
+// it has none, of the host agent (see "HostBroker") to the git
+// credential helper (see "RunHelper") over a Unix socket that
+// could only be reached by the agent and the "yolo" user.
+func ListenAndServe(
+	socketPath string,
+	store *Store,
+	hostBroker *HostBroker,
+) error {
+
+	if err := ensureSocketDir(filepath.Dir(socketPath)); err != nil {
+		return err
+	}
+
+	// Prevent "bind: address already in use" error
+	if err := os.RemoveAll(socketPath); err != nil {
+		return err
+	}
+
+	listener, err := net.Listen("unix", socketPath)
+
+	if err != nil {
+		return fmt.Errorf("failed to listen: %v", err)
+	}
+
+	defer listener.Close()
+
+	if err := os.Chmod(socketPath, 0660); err != nil {
+		return fmt.Errorf("failed to set socket permissions: %v", err)
+	}
+
+	if err := system.ChownToYoloUser(socketPath); err != nil {
+		logger.Warn(
+			"error when setting git credentials socket owner",
+			"error", err,
+		)
+	}
+
+	for {
+		conn, err := listener.Accept()
+
+		if err != nil {
+			return err
+		}
+
+		go handleConn(conn, store, hostBroker)
+	}
+}
+
+// ensureSocketDir creates the directory of the
+// socket, only accessible by the "yolo" user, so that the
+// socket could not be reached from the host nor by the
+// other users of the container.
+func ensureSocketDir(socketDirPath string) error {
+	if err := system.EnsurePrivateDir(socketDirPath); err != nil {
+		return err
+	}
+
+	if err := system.ChownToYoloUser(socketDirPath); err != nil {
+		logger.Warn(
+			"error when setting git credentials socket directory owner",
+			"error", err,
+		)
+	}
+
+	return nil
+}
+
+func handleConn(conn net.Conn, store *Store, hostBroker *HostBroker) {
+	defer conn.Close()
+
+	conn.SetDeadline(time.Now().Add(connTimeout))
+
+	reader := bufio.NewReader(conn)
+
+	operation, err := reader.ReadString('\n')
+
+	if err != nil {
+		logger.Warn("error when reading git credentials operation", "error", err)
+		return
+	}
+
+	req, err := readRequest(reader)
+
+	if err != nil {
+		logger.Warn("error when reading git credentials request", "error", err)
+		return
+	}
+
+	operation = strings.TrimSpace(operation)
+
+	ctx, cancel := context.WithTimeout(context.Background(), hostRequestTimeout)
+	defer cancel()
+
+	credentials, found := store.Lookup(req)
+	fromStore := found
+
+	switch {
+	case operation == "get" && !found:
+		credentials, found = hostBroker.Request(ctx, operation, req)
+	// The credentials of the store are only set by the agent
+	// so "store" and "erase" are only sent to the host agent
+	case operation != "get" && !fromStore:
+		hostBroker.Request(ctx, operation, req)
+	}
+
+	if operation != "get" {
+		credentials, found = Credentials{}, false
+	}
+
+	logger.Debug(
+		"git credentials requested",
+		"operation", operation,
+		"protocol", req.Protocol,
+		"host", req.Host,
+		"found", found,
+		"from_store", fromStore,
+	)
+
+	if err := writeCredentials(conn, credentials); err != nil {
+		logger.Warn("error when writing git credentials", "error", err)
+	}
+}
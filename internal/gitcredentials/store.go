@@ -0,0 +1,59 @@
+package gitcredentials
+
+import (
+	"strings"
+	"sync"
+)
+
+const (
+	githubHost = "github.com"
+	// The username is not checked by GitHub when
+	// a token is used but it must not be empty
+	githubTokenUsername = "x-access-token"
+)
+
+// Store keeps the credentials in memory only
+// so that they are never written to disk.
+type Store struct {
+	mutex       sync.RWMutex
+	githubToken string
+}
+
+func NewStore() *Store {
+	return &Store{}
+}
+
+// SetGitHubToken sets the token used for the HTTPS
+// requests to GitHub. An empty token removes it.
+func (s *Store) SetGitHubToken(token string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.githubToken = token
+}
+
+func (s *Store) HasGitHubToken() bool {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	return len(s.githubToken) > 0
+}
+
+// Lookup returns false when the store
+// has no credentials for the request.
+func (s *Store) Lookup(req Request) (Credentials, bool) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	if len(s.githubToken) == 0 ||
+		req.Protocol != "https" ||
+		!strings.EqualFold(req.Host, githubHost) {
+
+		return Credentials{}, false
+	}
+
+	return Credentials{
+		Username: githubTokenUsername,
+		Password: s.githubToken,
+	}, true
+}
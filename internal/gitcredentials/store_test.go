@@ -0,0 +1,72 @@
+package gitcredentials
+
+import (
+	"testing"
+)
+
+func TestStoreLookup(t *testing.T) {
+	testCases := []struct {
+		name                string
+		githubToken         string
+		req                 Request
+		expectedCredentials Credentials
+		expectedFound       bool
+	}{
+		{
+			name:        "github over https",
+			githubToken: "token",
+			req:         Request{Protocol: "https", Host: "github.com"},
+			expectedCredentials: Credentials{
+				Username: githubTokenUsername,
+				Password: "token",
+			},
+			expectedFound: true,
+		},
+		{
+			name:        "host case",
+			githubToken: "token",
+			req:         Request{Protocol: "https", Host: "GitHub.com"},
+			expectedCredentials: Credentials{
+				Username: githubTokenUsername,
+				Password: "token",
+			},
+			expectedFound: true,
+		},
+		{
+			name:        "http",
+			githubToken: "token",
+			req:         Request{Protocol: "http", Host: "github.com"},
+		},
+		{
+			name:        "other host",
+			githubToken: "token",
+			req:         Request{Protocol: "https", Host: "github.com.example.com"},
+		},
+		{
+			name:        "no token",
+			githubToken: "",
+			req:         Request{Protocol: "https", Host: "github.com"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			store := NewStore()
+			store.SetGitHubToken(tc.githubToken)
+
+			if store.HasGitHubToken() != (len(tc.githubToken) > 0) {
+				t.Fatalf("unexpected HasGitHubToken %t", store.HasGitHubToken())
+			}
+
+			credentials, found := store.Lookup(tc.req)
+
+			if found != tc.expectedFound {
+				t.Fatalf("expected found %t, got %t", tc.expectedFound, found)
+			}
+
+			if credentials != tc.expectedCredentials {
+				t.Fatalf("expected %+v, got %+v", tc.expectedCredentials, credentials)
+			}
+		})
+	}
+}
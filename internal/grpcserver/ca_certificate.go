@@ -0,0 +1,25 @@
+package grpcserver
+
+import (
+	"context"
+
+	"github.com/yolo-sh/agent-container/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (a *agentServer) GetCACertificate(
+	ctx context.Context,
+	req *proto.GetCACertificateRequest,
+) (*proto.GetCACertificateReply, error) {
+
+	workspaceCA, err := a.proxyManager.WorkspaceCA()
+
+	if err != nil {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+
+	return &proto.GetCACertificateReply{
+		CertificatePem: string(workspaceCA.CertificatePEM()),
+	}, nil
+}
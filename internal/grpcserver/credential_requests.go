@@ -0,0 +1,75 @@
+package grpcserver
+
+import (
+	"errors"
+	"io"
+
+	"github.com/yolo-sh/agent-container/internal/gitcredentials"
+	"github.com/yolo-sh/agent-container/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// CredentialRequests lets the host agent return the credentials
+// requested by the git credential helper, on demand, so that
+// the secrets are not stored in the container. Only the most
+// recent stream receives the requests: the previous one
+// is closed with the "Aborted" status code.
+func (a *agentServer) CredentialRequests(
+	stream proto.Agent_CredentialRequestsServer,
+) error {
+
+	session := a.gitCredentialsBroker.Connect()
+	defer session.Close()
+
+	logger.InfoContext(stream.Context(), "credential requests stream opened")
+	defer logger.InfoContext(stream.Context(), "credential requests stream closed")
+
+	recvErrChan := make(chan error, 1)
+
+	go func() {
+		for {
+			req, err := stream.Recv()
+
+			if err != nil {
+				recvErrChan <- err
+				return
+			}
+
+			session.Resolve(req.RequestId, gitcredentials.Credentials{
+				Username: req.Username,
+				Password: req.Password,
+			})
+		}
+	}()
+
+	for {
+		select {
+		case hostReq := <-session.Requests():
+			err := stream.Send(&proto.CredentialRequestsReply{
+				RequestId: hostReq.ID,
+				Operation: hostReq.Operation,
+				Protocol:  hostReq.Protocol,
+				Host:      hostReq.Host,
+				Path:      hostReq.Path,
+				Username:  hostReq.Username,
+				Password:  hostReq.Password,
+			})
+
+			if err != nil {
+				return err
+			}
+		case err := <-recvErrChan:
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+
+			return err
+		case <-session.Done():
+			return status.Error(
+				codes.Aborted,
+				"replaced by a more recent CredentialRequests stream",
+			)
+		}
+	}
+}
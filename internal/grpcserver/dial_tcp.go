@@ -0,0 +1,91 @@
+package grpcserver
+
+import (
+	"errors"
+	"net"
+
+	"github.com/yolo-sh/agent-container/internal/network"
+	"github.com/yolo-sh/agent-container/internal/tunnel"
+	"github.com/yolo-sh/agent-container/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// DialTCP tunnels a connection to a container address
+// over the agent socket. It lets the host agent forward ports
+// when the container IP address is not routable from the host
+// (eg: Docker Desktop, rootless setups, remote VMs).
+func (a *agentServer) DialTCP(stream proto.Agent_DialTCPServer) error {
+	firstReq, err := stream.Recv()
+
+	if err != nil {
+		return err
+	}
+
+	resolvedAddr, err := network.ResolveContainerLocalAddr(
+		stream.Context(),
+		firstReq.Addr,
+	)
+
+	if err != nil && errors.Is(err, network.ErrNotContainerLocalAddr) {
+		return status.Error(codes.PermissionDenied, err.Error())
+	}
+
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(
+		stream.Context(),
+		"tcp",
+		resolvedAddr,
+	)
+
+	if err != nil {
+		return status.Error(codes.Unavailable, err.Error())
+	}
+
+	return tunnel.Pipe(
+		conn,
+		&dialTCPFrameStream{
+			stream:     stream,
+			firstFrame: firstReq.Frame,
+		},
+	)
+}
+
+// dialTCPFrameStream adapts the DialTCP stream
+// to the interface expected by the tunnel package.
+type dialTCPFrameStream struct {
+	stream proto.Agent_DialTCPServer
+	// The first request may also carry data
+	firstFrame *proto.TunnelFrame
+}
+
+func (d *dialTCPFrameStream) SendFrame(frame *proto.TunnelFrame) error {
+	return d.stream.Send(&proto.DialTCPReply{
+		Frame: frame,
+	})
+}
+
+func (d *dialTCPFrameStream) RecvFrame() (*proto.TunnelFrame, error) {
+	if d.firstFrame != nil {
+		firstFrame := d.firstFrame
+		d.firstFrame = nil
+
+		return firstFrame, nil
+	}
+
+	req, err := d.stream.Recv()
+
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Frame == nil { // Nothing to forward
+		return &proto.TunnelFrame{}, nil
+	}
+
+	return req.Frame, nil
+}
@@ -1,110 +1,435 @@
 package grpcserver
 
 import (
-	"bufio"
+	"bytes"
+	"context"
 	_ "embed"
 	"errors"
 	"fmt"
-	"io"
 	"os"
 	"os/exec"
 	"path"
+	"time"
 
 	"github.com/yolo-sh/agent-container/constants"
 	"github.com/yolo-sh/agent-container/entities"
 	"github.com/yolo-sh/agent-container/internal/env"
+	"github.com/yolo-sh/agent-container/internal/initrun"
+	"github.com/yolo-sh/agent-container/internal/logging"
+	"github.com/yolo-sh/agent-container/internal/metrics"
+	"github.com/yolo-sh/agent-container/internal/system"
 	"github.com/yolo-sh/agent-container/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 //go:embed init.sh
 var initScript string
 
-func (*agentServer) Init(
+const (
+	initPhaseScript    = "script"
+	initPhaseKeys      = "keys"
+	initPhaseAccess    = "access"
+	initPhaseWorkspace = "workspace"
+)
+
+const (
+	initConcurrencyModeWait     = "wait"
+	initConcurrencyModeAttach   = "attach"
+	initConcurrencyModeFailFast = "fail_fast"
+)
+
+var initLogger = logging.Component(logging.ComponentInit)
+
+// Init starts an Init run and streams its events.
+// The run goes on when the stream is closed before it
+// has finished (see "AttachInitRun" to reattach to it).
+// It is only bounded by "timeout_ms".
+// Only one run could run at a time (see "concurrency_mode").
+func (a *agentServer) Init(
 	req *proto.InitRequest,
 	stream proto.Agent_InitServer,
 ) error {
 
-	err := stream.Send(&proto.InitReply{
-		LogLineHeader: fmt.Sprintf(
-			"Executing %s",
-			constants.InitScriptRepoPath,
-		),
-	})
+	if err := validateInitRequest(req); err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if req.ForwardSshAgent && !a.sshAgentForwarded.Load() {
+		return status.Error(
+			codes.FailedPrecondition,
+			"no SSH agent is forwarded (see ForwardSSHAgent)",
+		)
+	}
+
+	unlockWorkspace, err := env.TryLockWorkspace()
+
+	if errors.Is(err, env.ErrWorkspaceLocked) {
+		switch req.ConcurrencyMode {
+		case initConcurrencyModeFailFast:
+			return status.Error(codes.FailedPrecondition, err.Error())
+		case initConcurrencyModeAttach:
+			if runningRun := a.initRunManager.Running(); runningRun != nil {
+				return followInitRun(stream, runningRun, 0)
+			}
+		}
+
+		// Locked by another process or
+		// by a run that is about to start
+		err = stream.Send(&proto.InitReply{
+			LogLineHeader: "Waiting for the running workspace operation to finish",
+		})
+
+		if err != nil {
+			return err
+		}
+
+		unlockWorkspace, err = env.LockWorkspace(stream.Context())
+	}
 
 	if err != nil {
+		if ctxErr := stream.Context().Err(); ctxErr != nil {
+			return status.FromContextError(ctxErr).Err()
+		}
+
 		return err
 	}
 
-	initScriptFilePath, err := createInitScriptFile()
+	// The run could outlive the stream. Its logs keep the
+	// request ID (see "streamLoggingInterceptor").
+	runCtx := logging.ContextWithRequestID(
+		context.Background(),
+		logging.RequestIDFromContext(stream.Context()),
+	)
+
+	var cancelRun context.CancelFunc
+
+	if req.TimeoutMs > 0 {
+		runCtx, cancelRun = context.WithTimeout(
+			runCtx,
+			time.Duration(req.TimeoutMs)*time.Millisecond,
+		)
+	} else {
+		runCtx, cancelRun = context.WithCancel(runCtx)
+	}
+
+	run, err := a.initRunManager.Start(
+		req.EnvRepoOwner,
+		req.EnvRepoName,
+		func(run *initrun.Run) error {
+			defer unlockWorkspace()
+			defer cancelRun()
+
+			// Persisted with the run (see "AttachInitRun")
+			return buildInitRunStatusErr(
+				run,
+				a.runInit(runCtx, req, run),
+			)
+		},
+	)
 
 	if err != nil {
+		unlockWorkspace()
+		cancelRun()
+
 		return err
 	}
 
-	defer os.Remove(initScriptFilePath)
+	return followInitRun(stream, run, 0)
+}
+
+func validateInitRequest(req *proto.InitRequest) error {
+	switch req.ConcurrencyMode {
+	case "",
+		initConcurrencyModeWait,
+		initConcurrencyModeAttach,
+		initConcurrencyModeFailFast:
+	default:
+		return fmt.Errorf("unknown concurrency mode \"%s\"", req.ConcurrencyMode)
+	}
+
+	if req.ForwardSshAgent && len(req.GithubHttpsToken) > 0 {
+		return errors.New("the SSH agent could not be forwarded when a GitHub HTTPS token is used")
+	}
+
+	if req.TimeoutMs < 0 {
+		return fmt.Errorf("invalid timeout %dms", req.TimeoutMs)
+	}
+
+	for phase, phaseTimeoutMs := range req.PhaseTimeoutsMs {
+		if phase != initPhaseScript &&
+			phase != initPhaseKeys &&
+			phase != initPhaseAccess &&
+			phase != initPhaseWorkspace {
 
-	initCmd := buildInitCmd(initScriptFilePath, req)
+			return fmt.Errorf("unknown init phase \"%s\"", phase)
+		}
+
+		if phaseTimeoutMs < 0 {
+			return fmt.Errorf(
+				"invalid timeout %dms for init phase \"%s\"",
+				phaseTimeoutMs,
+				phase,
+			)
+		}
+	}
+
+	return nil
+}
+
+func (a *agentServer) runInit(
+	ctx context.Context,
+	req *proto.InitRequest,
+	run *initrun.Run,
+) (initErr error) {
+
+	initStartedAt := time.Now()
+
+	initLogger.InfoContext(
+		ctx,
+		"init started",
+		"run_id", run.ID(),
+		"repo_owner", req.EnvRepoOwner,
+		"repo_name", req.EnvRepoName,
+	)
+
+	defer func() {
+		initStatus := metrics.InitStatusSuccess
+
+		if initErr != nil {
+			initStatus = metrics.InitStatusFailure
+		}
+
+		initDuration := time.Since(initStartedAt)
+
+		metrics.InitDuration.WithLabelValues(initStatus).Observe(
+			initDuration.Seconds(),
+		)
+
+		if initErr != nil {
+			initLogger.ErrorContext(
+				ctx,
+				"init failed",
+				"run_id", run.ID(),
+				"duration", initDuration,
+				"error", initErr,
+			)
+
+			return
+		}
+
+		initLogger.InfoContext(
+			ctx,
+			"init finished",
+			"run_id", run.ID(),
+			"duration", initDuration,
+		)
+	}()
 
-	stdoutReader, err := buildInitCmdStdoutReader(initCmd)
+	gitAuth := a.setUpGitAuth(req)
+
+	err := runInitPhase(ctx, req, initPhaseScript, func(ctx context.Context) error {
+		return runInitScript(ctx, req, gitAuth, run)
+	})
 
 	if err != nil {
 		return err
 	}
 
-	stderrReader, err := buildInitCmdStderrReader(initCmd)
+	err = runInitPhase(ctx, req, initPhaseKeys, func(ctx context.Context) error {
+		return sendGitHubPublicKeys(run, gitAuth)
+	})
 
 	if err != nil {
 		return err
 	}
 
-	if err := initCmd.Start(); err != nil {
+	err = runInitPhase(ctx, req, initPhaseAccess, func(ctx context.Context) error {
+		return env.CheckGitHubRepoAccess(
+			ctx,
+			req.EnvRepoOwner,
+			req.EnvRepoName,
+			gitAuth,
+			a.config.GitAccessCheckPolicy,
+			initRunProgressReporter{run: run},
+		)
+	})
+
+	if err != nil {
 		return err
 	}
 
-	stdoutHandlerChan := make(chan error, 1)
+	return runInitPhase(ctx, req, initPhaseWorkspace, func(ctx context.Context) error {
+		workspaceConfig := entities.NewWorkspaceConfig()
 
-	go func() {
-		stdoutHandlerChan <- handleInitCmdOutput(
-			stdoutReader,
-			stream,
+		return env.PrepareWorkspace(
+			ctx,
+			workspaceConfig,
+			req.EnvRepoOwner,
+			req.EnvRepoName,
+			gitAuth,
+			req.EnvRepoLanguagesUsed,
 		)
-	}()
+	})
+}
 
-	stderrHandlerChan := make(chan error, 1)
+func runInitPhase(
+	ctx context.Context,
+	req *proto.InitRequest,
+	phase string,
+	phaseFunc func(ctx context.Context) error,
+) error {
 
-	go func() {
-		stderrHandlerChan <- handleInitCmdOutput(
-			stderrReader,
-			stream,
-		)
-	}()
+	phaseCtx := ctx
+	phaseTimeout := time.Duration(req.PhaseTimeoutsMs[phase]) * time.Millisecond
 
-	stdoutHandlerErr := <-stdoutHandlerChan
-	stderrHandlerErr := <-stderrHandlerChan
+	if phaseTimeout > 0 {
+		var cancelPhase context.CancelFunc
 
-	if stdoutHandlerErr != nil {
-		return stdoutHandlerErr
+		phaseCtx, cancelPhase = context.WithTimeout(ctx, phaseTimeout)
+		defer cancelPhase()
 	}
 
-	if stderrHandlerErr != nil {
-		return stderrHandlerErr
+	phaseStartedAt := time.Now()
+
+	err := phaseFunc(phaseCtx)
+
+	phaseDuration := time.Since(phaseStartedAt)
+
+	// The errors returned once the context is done
+	// (eg: "signal: killed") are not meaningful
+	if err != nil && phaseCtx.Err() != nil {
+		err = buildInitPhaseCtxErr(ctx, phaseCtx, phase, phaseTimeout)
 	}
 
-	// It is incorrect to call Wait
-	// before all reads from the pipes have completed.
-	// See StderrPipe() / StdoutPipe() documentation.
-	if err := initCmd.Wait(); err != nil {
-		return err
+	metrics.InitPhaseDuration.WithLabelValues(phase).Observe(
+		phaseDuration.Seconds(),
+	)
+
+	if err != nil {
+		metrics.InitPhaseFailures.WithLabelValues(phase).Inc()
+
+		initLogger.WarnContext(
+			ctx,
+			"init phase failed",
+			"phase", phase,
+			"duration", phaseDuration,
+			"error", err,
+		)
+
+		return &initPhaseError{
+			phase: phase,
+			err:   err,
+		}
 	}
 
-	githubSSHPublicKeyContent, err := readGitHubSSHPublicKey(
-		constants.GitHubPublicSSHKeyFilePath,
+	initLogger.DebugContext(
+		ctx,
+		"init phase finished",
+		"phase", phase,
+		"duration", phaseDuration,
 	)
 
+	return nil
+}
+
+func buildInitPhaseCtxErr(
+	ctx context.Context,
+	phaseCtx context.Context,
+	phase string,
+	phaseTimeout time.Duration,
+) error {
+
+	if errors.Is(ctx.Err(), context.Canceled) {
+		return fmt.Errorf(
+			"init canceled during phase \"%s\": %w",
+			phase,
+			ctx.Err(),
+		)
+	}
+
+	if ctx.Err() != nil {
+		return fmt.Errorf(
+			"init timed out during phase \"%s\": %w",
+			phase,
+			ctx.Err(),
+		)
+	}
+
+	return fmt.Errorf(
+		"init phase \"%s\" timed out after %s: %w",
+		phase,
+		phaseTimeout,
+		phaseCtx.Err(),
+	)
+}
+
+func runInitScript(
+	ctx context.Context,
+	req *proto.InitRequest,
+	gitAuth env.GitAuth,
+	run *initrun.Run,
+) error {
+
+	run.Append(initrun.Event{
+		LogLineHeader: fmt.Sprintf(
+			"Executing %s",
+			constants.InitScriptRepoPath,
+		),
+	})
+
+	initScriptFilePath, err := createInitScriptFile()
+
 	if err != nil {
 		return err
 	}
 
+	defer os.Remove(initScriptFilePath)
+
+	initCmd := buildInitCmd(ctx, initScriptFilePath, req, gitAuth)
+
+	stderrTail := newOutputTail(initScriptStderrTailMaxLines)
+
+	// Writers (instead of pipes) so that the output is copied
+	// by "Wait" which stops waiting for it "WaitDelay" after
+	// the command is killed (see "system.CommandContext")
+	stdoutWriter := newInitCmdOutputWriter(run, nil)
+	stderrWriter := newInitCmdOutputWriter(run, stderrTail)
+
+	initCmd.Stdout = stdoutWriter
+	initCmd.Stderr = stderrWriter
+
+	err = initCmd.Run()
+
+	stdoutWriter.flush()
+	stderrWriter.flush()
+
+	if err != nil {
+		return &initScriptError{
+			err:        err,
+			stderrTail: stderrTail.String(),
+		}
+	}
+
+	return nil
+}
+
+// The SSH key is not generated when the SSH agent is forwarded
+func sendGitHubPublicKeys(run *initrun.Run, gitAuth env.GitAuth) error {
+	var githubSSHPublicKeyContent *string
+
+	if gitAuth.Transport() != env.GitTransportSSHAgent {
+		sshPublicKeyContent, err := readGitHubSSHPublicKey(
+			constants.GitHubPublicSSHKeyFilePath,
+		)
+
+		if err != nil {
+			return err
+		}
+
+		githubSSHPublicKeyContent = &sshPublicKeyContent
+	}
+
 	githubGPGPublicKeyContent, err := readGitHubGPGPublicKey(
 		constants.GitHubPublicGPGKeyFilePath,
 	)
@@ -113,23 +438,52 @@ func (*agentServer) Init(
 		return err
 	}
 
-	err = stream.Send(&proto.InitReply{
-		GithubSshPublicKeyContent: &githubSSHPublicKeyContent,
-		GithubGpgPublicKeyContent: &githubGPGPublicKeyContent,
+	run.Append(initrun.Event{
+		GitHubSSHPublicKeyContent: githubSSHPublicKeyContent,
+		GitHubGPGPublicKeyContent: &githubGPGPublicKeyContent,
 	})
 
-	if err != nil {
-		return err
+	return nil
+}
+
+// setUpGitAuth keeps the HTTPS token of the request, if any,
+// in the credentials store (the token of the previous run is
+// removed otherwise) and returns how the repositories are accessed
+// (with the forwarded SSH agent, the token or the generated key)
+func (a *agentServer) setUpGitAuth(req *proto.InitRequest) env.GitAuth {
+	a.gitCredentialsStore.SetGitHubToken(req.GithubHttpsToken)
+
+	if req.ForwardSshAgent {
+		return env.GitAuth{
+			SSHAgentSocketPath: constants.SSHAgentSocketPath,
+		}
 	}
 
-	workspaceConfig := entities.NewWorkspaceConfig()
+	if len(req.GithubHttpsToken) == 0 {
+		return env.GitAuth{}
+	}
 
-	return env.PrepareWorkspace(
-		workspaceConfig,
-		req.EnvRepoOwner,
-		req.EnvRepoName,
-		req.EnvRepoLanguagesUsed,
-	)
+	return env.GitAuth{
+		HTTPSCredentialHelper: constants.GitCredentialHelperPath,
+	}
+}
+
+// initRunProgressReporter adds the progress
+// of the workspace operations to an Init run
+type initRunProgressReporter struct {
+	run *initrun.Run
+}
+
+func (i initRunProgressReporter) ReportHeader(header string) {
+	i.run.Append(initrun.Event{
+		LogLineHeader: header,
+	})
+}
+
+func (i initRunProgressReporter) ReportLine(line string) {
+	i.run.Append(initrun.Event{
+		LogLine: line + "\n",
+	})
 }
 
 func createInitScriptFile() (string, error) {
@@ -179,71 +533,90 @@ func addExecPermsToInitScriptFile(initScriptFile *os.File) error {
 }
 
 func buildInitCmd(
+	ctx context.Context,
 	initScriptFilePath string,
 	req *proto.InitRequest,
+	gitAuth env.GitAuth,
 ) *exec.Cmd {
 
-	initCmd := exec.Command(initScriptFilePath)
+	initCmd := system.CommandContext(ctx, initScriptFilePath)
 
 	initCmd.Dir = path.Dir(initScriptFilePath)
-	initCmd.Env = buildInitCmdEnvVars(req)
+	initCmd.Env = buildInitCmdEnvVars(req, gitAuth)
 
 	return initCmd
 }
 
-func buildInitCmdEnvVars(req *proto.InitRequest) []string {
+func buildInitCmdEnvVars(
+	req *proto.InitRequest,
+	gitAuth env.GitAuth,
+) []string {
+
 	return []string{
 		fmt.Sprintf("GITHUB_USER_EMAIL=%s", req.GithubUserEmail),
 		fmt.Sprintf("USER_FULL_NAME=%s", req.UserFullName),
+		fmt.Sprintf("GIT_CREDENTIAL_HELPER=%s", constants.GitCredentialHelperPath),
+		fmt.Sprintf("GIT_TRANSPORT=%s", gitAuth.Transport()),
+		fmt.Sprintf("SSH_AGENT_SOCKET_PATH=%s", constants.SSHAgentSocketPath),
 	}
 }
 
-func buildInitCmdStderrReader(initCmd *exec.Cmd) (*bufio.Reader, error) {
-	stderrPipe, err := initCmd.StderrPipe()
-
-	if err != nil {
-		return nil, err
-	}
-
-	return bufio.NewReader(stderrPipe), nil
+// initCmdOutputWriter appends each line written
+// by the init script to the run (and to "tail", if any).
+// Used by one goroutine at a time (see "exec.Cmd").
+type initCmdOutputWriter struct {
+	run         *initrun.Run
+	tail        *outputTail
+	partialLine []byte
 }
 
-func buildInitCmdStdoutReader(initCmd *exec.Cmd) (*bufio.Reader, error) {
-	stdoutPipe, err := initCmd.StdoutPipe()
+func newInitCmdOutputWriter(
+	run *initrun.Run,
+	tail *outputTail,
+) *initCmdOutputWriter {
 
-	if err != nil {
-		return nil, err
+	return &initCmdOutputWriter{
+		run:  run,
+		tail: tail,
 	}
-
-	return bufio.NewReader(stdoutPipe), nil
 }
 
-func handleInitCmdOutput(
-	outputReader *bufio.Reader,
-	stream proto.Agent_InitServer,
-) error {
+func (i *initCmdOutputWriter) Write(p []byte) (int, error) {
+	i.partialLine = append(i.partialLine, p...)
 
 	for {
-		outputLine, err := outputReader.ReadString('\n')
+		lineEnd := bytes.IndexByte(i.partialLine, '\n')
 
-		if err != nil && errors.Is(err, io.EOF) {
+		if lineEnd == -1 {
 			break
 		}
 
-		if err != nil {
-			return err
-		}
+		i.appendLine(string(i.partialLine[:lineEnd+1]))
+		i.partialLine = i.partialLine[lineEnd+1:]
+	}
 
-		err = stream.Send(&proto.InitReply{
-			LogLine: outputLine,
-		})
+	return len(p), nil
+}
 
-		if err != nil {
-			return err
-		}
+// flush appends the last line when it
+// doesn't end with a newline character
+func (i *initCmdOutputWriter) flush() {
+	if len(i.partialLine) == 0 {
+		return
 	}
 
-	return nil
+	i.appendLine(string(i.partialLine))
+	i.partialLine = nil
+}
+
+func (i *initCmdOutputWriter) appendLine(outputLine string) {
+	i.run.Append(initrun.Event{
+		LogLine: outputLine,
+	})
+
+	if i.tail != nil {
+		i.tail.add(outputLine)
+	}
 }
 
 func readGitHubSSHPublicKey(sshPublicKeyFilePath string) (string, error) {
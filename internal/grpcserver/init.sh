@@ -23,27 +23,47 @@ log "Configuring workspace for user \"yolo\""
 sudo --set-home --login --user yolo -- env \
 	GITHUB_USER_EMAIL="${GITHUB_USER_EMAIL}" \
 	USER_FULL_NAME="${USER_FULL_NAME}" \
+	GIT_CREDENTIAL_HELPER="${GIT_CREDENTIAL_HELPER}" \
+	GIT_TRANSPORT="${GIT_TRANSPORT}" \
+	SSH_AGENT_SOCKET_PATH="${SSH_AGENT_SOCKET_PATH}" \
 bash << 'EOF'
 
-if [[ ! -f ".ssh/yolo-github" ]]; then
-	ssh-keygen -t ed25519 -C "${GITHUB_USER_EMAIL}" -f .ssh/yolo-github -q -N ""
-fi
+# The SSH agent forwarded from the host is used instead of the generated key
+if [[ "${GIT_TRANSPORT}" == "ssh_agent" ]]; then
+	SSH_IDENTITY_CONFIG="  IdentityAgent ${SSH_AGENT_SOCKET_PATH}"
+else
+	if [[ ! -f ".ssh/yolo-github" ]]; then
+		ssh-keygen -t ed25519 -C "${GITHUB_USER_EMAIL}" -f .ssh/yolo-github -q -N ""
+	fi
+
+	chmod 644 .ssh/yolo-github.pub
+	chmod 600 .ssh/yolo-github
 
-chmod 644 .ssh/yolo-github.pub
-chmod 600 .ssh/yolo-github
+	SSH_IDENTITY_CONFIG="  IdentityFile ~/.ssh/yolo-github"
+fi
 
-if ! grep --silent --fixed-strings "IdentityFile ~/.ssh/yolo-github" .ssh/config; then
+if ! grep --silent --fixed-strings "${SSH_IDENTITY_CONFIG}" .ssh/config; then
 	rm --force .ssh/config
   echo "Host github.com" >> .ssh/config
 	echo "  User git" >> .ssh/config
 	echo "  Hostname github.com" >> .ssh/config
 	echo "  PreferredAuthentications publickey" >> .ssh/config
-	echo "  IdentityFile ~/.ssh/yolo-github" >> .ssh/config
+	echo "${SSH_IDENTITY_CONFIG}" >> .ssh/config
 fi
 
 chmod 600 .ssh/config
 
-if ! grep --silent --fixed-strings "github.com" .ssh/known_hosts; then
+# Let the other SSH clients use the forwarded SSH agent too.
+# The socket only exists while the agent is forwarded.
+touch .bashrc
+sed --in-place '/# yolo-ssh-agent$/d' .bashrc
+
+if [[ "${GIT_TRANSPORT}" == "ssh_agent" ]]; then
+	echo "if [[ -S \"${SSH_AGENT_SOCKET_PATH}\" ]]; then export SSH_AUTH_SOCK=\"${SSH_AGENT_SOCKET_PATH}\"; fi # yolo-ssh-agent" >> .bashrc
+fi
+
+# SSH may be blocked when the repositories are accessed over HTTPS
+if [[ "${GIT_TRANSPORT}" != "https" ]] && ! grep --silent --fixed-strings "github.com" .ssh/known_hosts; then
   ssh-keyscan github.com >> .ssh/known_hosts
 fi
 
@@ -88,4 +108,15 @@ git config --global user.email "${GITHUB_USER_EMAIL}"
 git config --global user.signingkey "${GIT_GPG_KEY_ID}"
 git config --global commit.gpgsign true
 
+# Get the HTTPS credentials from the container agent (which asks
+# the host agent when needed) so that they are never written to disk
+git config --global --unset-all credential.helper "git-credential-yolo" || true
+git config --global --add credential.helper "${GIT_CREDENTIAL_HELPER}"
+
+git config --global --unset-all url.https://github.com/.insteadOf || true
+
+if [[ "${GIT_TRANSPORT}" == "https" ]]; then
+	git config --global url.https://github.com/.insteadOf "git@github.com:"
+fi
+
 EOF
@@ -0,0 +1,207 @@
+package grpcserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/yolo-sh/agent-container/constants"
+	"github.com/yolo-sh/agent-container/internal/env"
+	"github.com/yolo-sh/agent-container/internal/initrun"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// The domain of the "ErrorInfo" details
+const initErrorDomain = "agent-container.yolo.sh"
+
+// The number of stderr lines of the init script kept in the errors
+const initScriptStderrTailMaxLines = 20
+
+// The reasons of the "ErrorInfo" details
+const (
+	initErrorReasonCanceled             = "INIT_CANCELED"
+	initErrorReasonTimeout              = "INIT_TIMEOUT"
+	initErrorReasonGitHostKeyMismatch   = "GIT_HOST_KEY_MISMATCH"
+	initErrorReasonGitHostKeyUnknown    = "GIT_HOST_KEY_UNKNOWN"
+	initErrorReasonGitAuthFailed        = "GIT_AUTH_FAILED"
+	initErrorReasonGitRepoNotFound      = "GIT_REPO_NOT_FOUND"
+	initErrorReasonGitRemoteUnreachable = "GIT_REMOTE_UNREACHABLE"
+	initErrorReasonScriptFailed         = "INIT_SCRIPT_FAILED"
+	initErrorReasonUnknown              = "INIT_FAILED"
+)
+
+// initPhaseError is returned when a phase of an Init run has failed
+type initPhaseError struct {
+	phase string
+	err   error
+}
+
+func (i *initPhaseError) Error() string {
+	return i.err.Error()
+}
+
+func (i *initPhaseError) Unwrap() error {
+	return i.err
+}
+
+// initScriptError is returned when the init script has failed
+type initScriptError struct {
+	err        error
+	stderrTail string
+}
+
+func (i *initScriptError) Error() string {
+	return fmt.Sprintf("error while running the init script: %v", i.err)
+}
+
+func (i *initScriptError) Unwrap() error {
+	return i.err
+}
+
+// buildInitRunStatusErr maps the error an Init run has finished
+// with to a gRPC status with an "ErrorInfo" detail (which phase
+// has failed and why) and, if any, "Help" and "DebugInfo"
+// (the last lines written to stderr) details.
+// The runs are finished with this status error so
+// that it is persisted with them (see "initrun.Info").
+func buildInitRunStatusErr(run *initrun.Run, runErr error) error {
+	if runErr == nil {
+		return nil
+	}
+
+	// Already built (eg: a run loaded from disk)
+	if _, ok := status.FromError(runErr); ok {
+		return runErr
+	}
+
+	code, reason := classifyInitRunErr(runErr)
+
+	errorInfo := &errdetails.ErrorInfo{
+		Reason: reason,
+		Domain: initErrorDomain,
+		Metadata: map[string]string{
+			"run_id": run.ID(),
+		},
+	}
+
+	details := []proto.Message{errorInfo}
+
+	var phaseErr *initPhaseError
+
+	if errors.As(runErr, &phaseErr) {
+		errorInfo.Metadata["phase"] = phaseErr.phase
+	}
+
+	var gitErr *env.GitError
+	var scriptErr *initScriptError
+
+	stderrTail := ""
+
+	if errors.As(runErr, &gitErr) {
+		errorInfo.Metadata["repo"] = gitErr.RepoOwner + "/" + gitErr.RepoName
+		stderrTail = gitErr.StderrTail
+	} else if errors.As(runErr, &scriptErr) {
+		stderrTail = scriptErr.stderrTail
+	}
+
+	// The token is rejected when using HTTPS
+	if reason == initErrorReasonGitAuthFailed &&
+		gitErr != nil && gitErr.Transport == env.GitTransportSSH {
+
+		// Best effort, the key may not have been generated
+		sshPublicKey, err := readGitHubSSHPublicKey(
+			constants.GitHubPublicSSHKeyFilePath,
+		)
+
+		if err == nil {
+			errorInfo.Metadata["github_ssh_public_key"] = strings.TrimSpace(sshPublicKey)
+		}
+
+		details = append(details, &errdetails.Help{
+			Links: []*errdetails.Help_Link{
+				{
+					Description: "Add the SSH public key generated in the environment to your GitHub account",
+					Url:         "https://github.com/settings/ssh/new",
+				},
+			},
+		})
+	}
+
+	if len(stderrTail) > 0 {
+		details = append(details, &errdetails.DebugInfo{
+			Detail: stderrTail,
+		})
+	}
+
+	runStatus, err := status.New(code, runErr.Error()).WithDetails(details...)
+
+	if err != nil {
+		return status.Error(code, runErr.Error())
+	}
+
+	return runStatus.Err()
+}
+
+func classifyInitRunErr(runErr error) (codes.Code, string) {
+	switch {
+	case errors.Is(runErr, context.Canceled):
+		return codes.Canceled, initErrorReasonCanceled
+	case errors.Is(runErr, context.DeadlineExceeded):
+		return codes.DeadlineExceeded, initErrorReasonTimeout
+	case errors.Is(runErr, env.ErrGitHostKeyMismatch):
+		return codes.FailedPrecondition, initErrorReasonGitHostKeyMismatch
+	case errors.Is(runErr, env.ErrGitHostKeyUnknown):
+		return codes.FailedPrecondition, initErrorReasonGitHostKeyUnknown
+	case errors.Is(runErr, env.ErrGitAuthFailed):
+		return codes.PermissionDenied, initErrorReasonGitAuthFailed
+	case errors.Is(runErr, env.ErrGitRepoNotFound):
+		return codes.NotFound, initErrorReasonGitRepoNotFound
+	case errors.Is(runErr, env.ErrGitNetworkUnreachable):
+		return codes.Unavailable, initErrorReasonGitRemoteUnreachable
+	}
+
+	var scriptErr *initScriptError
+
+	if errors.As(runErr, &scriptErr) {
+		return codes.Internal, initErrorReasonScriptFailed
+	}
+
+	return codes.Unknown, initErrorReasonUnknown
+}
+
+// outputTail keeps the last lines of a command output
+type outputTail struct {
+	mutex    sync.Mutex
+	maxLines int
+	lines    []string
+}
+
+func newOutputTail(maxLines int) *outputTail {
+	return &outputTail{
+		maxLines: maxLines,
+		lines:    []string{},
+	}
+}
+
+func (o *outputTail) add(line string) {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	o.lines = append(o.lines, strings.TrimRight(line, "\n"))
+
+	if len(o.lines) > o.maxLines {
+		o.lines = o.lines[len(o.lines)-o.maxLines:]
+	}
+}
+
+func (o *outputTail) String() string {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	return strings.Join(o.lines, "\n")
+}
@@ -0,0 +1,121 @@
+package grpcserver
+
+import (
+	"context"
+	"errors"
+
+	"github.com/yolo-sh/agent-container/internal/initrun"
+	"github.com/yolo-sh/agent-container/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// initRunStream is implemented by the
+// "Init" and "AttachInitRun" streams
+type initRunStream interface {
+	Context() context.Context
+	Send(*proto.InitReply) error
+}
+
+func (a *agentServer) GetInitRun(
+	ctx context.Context,
+	req *proto.GetInitRunRequest,
+) (*proto.GetInitRunReply, error) {
+
+	run, err := a.getInitRun(req.RunId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	info := run.Info()
+
+	protoRun := &proto.InitRun{
+		Id:              info.ID,
+		Status:          string(info.Status),
+		EnvRepoOwner:    info.RepoOwner,
+		EnvRepoName:     info.RepoName,
+		StartedAtUnixMs: info.StartedAt.UnixMilli(),
+		Error:           info.Error,
+		EventsCount:     info.EventsCount,
+	}
+
+	if !info.FinishedAt.IsZero() {
+		protoRun.FinishedAtUnixMs = info.FinishedAt.UnixMilli()
+	}
+
+	return &proto.GetInitRunReply{
+		Run: protoRun,
+	}, nil
+}
+
+// AttachInitRun replays the events of a running or
+// finished Init run, starting at the requested offset.
+// Like "Init", it returns once the run has finished,
+// with the error the run has finished with.
+func (a *agentServer) AttachInitRun(
+	req *proto.AttachInitRunRequest,
+	stream proto.Agent_AttachInitRunServer,
+) error {
+
+	run, err := a.getInitRun(req.RunId)
+
+	if err != nil {
+		return err
+	}
+
+	return followInitRun(stream, run, req.Offset)
+}
+
+func (a *agentServer) getInitRun(runID string) (*initrun.Run, error) {
+	run, err := a.initRunManager.Get(runID)
+
+	if errors.Is(err, initrun.ErrInitRunNotFound) {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return run, nil
+}
+
+func followInitRun(
+	stream initRunStream,
+	run *initrun.Run,
+	offset uint64,
+) error {
+
+	err := run.Follow(
+		stream.Context(),
+		offset,
+		func(event initrun.Event) error {
+			return stream.Send(&proto.InitReply{
+				LogLineHeader:             event.LogLineHeader,
+				LogLine:                   event.LogLine,
+				GithubSshPublicKeyContent: event.GitHubSSHPublicKeyContent,
+				GithubGpgPublicKeyContent: event.GitHubGPGPublicKeyContent,
+				RunId:                     run.ID(),
+				Offset:                    event.Offset,
+			})
+		},
+	)
+
+	if err != nil {
+		initLogger.InfoContext(
+			stream.Context(),
+			"init run stream closed",
+			"run_id", run.ID(),
+			"error", err,
+		)
+
+		if ctxErr := stream.Context().Err(); ctxErr != nil {
+			return status.FromContextError(ctxErr).Err()
+		}
+
+		return err
+	}
+
+	return buildInitRunStatusErr(run, run.Err())
+}
@@ -0,0 +1,262 @@
+package grpcserver
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/yolo-sh/agent-container/internal/initrun"
+	"github.com/yolo-sh/agent-container/proto"
+)
+
+func TestValidateInitRequest(t *testing.T) {
+	testCases := []struct {
+		name          string
+		req           *proto.InitRequest
+		expectedError bool
+	}{
+		{
+			name: "timeouts",
+			req: &proto.InitRequest{
+				TimeoutMs: 60000,
+				PhaseTimeoutsMs: map[string]int64{
+					initPhaseScript:    30000,
+					initPhaseWorkspace: 0,
+				},
+			},
+		},
+		{
+			name: "negative timeout",
+			req: &proto.InitRequest{
+				TimeoutMs: -1,
+			},
+			expectedError: true,
+		},
+		{
+			name: "negative phase timeout",
+			req: &proto.InitRequest{
+				PhaseTimeoutsMs: map[string]int64{
+					initPhaseAccess: -1,
+				},
+			},
+			expectedError: true,
+		},
+		{
+			name: "unknown phase",
+			req: &proto.InitRequest{
+				PhaseTimeoutsMs: map[string]int64{
+					"clone": 1000,
+				},
+			},
+			expectedError: true,
+		},
+		{
+			name: "concurrency mode",
+			req: &proto.InitRequest{
+				ConcurrencyMode: initConcurrencyModeFailFast,
+			},
+		},
+		{
+			name: "unknown concurrency mode",
+			req: &proto.InitRequest{
+				ConcurrencyMode: "queue",
+			},
+			expectedError: true,
+		},
+		{
+			name: "forwarded SSH agent",
+			req: &proto.InitRequest{
+				ForwardSshAgent: true,
+			},
+		},
+		{
+			name: "forwarded SSH agent with HTTPS token",
+			req: &proto.InitRequest{
+				ForwardSshAgent:  true,
+				GithubHttpsToken: "token",
+			},
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateInitRequest(tc.req)
+
+			if tc.expectedError && err == nil {
+				t.Fatal("expected an error")
+			}
+
+			if !tc.expectedError && err != nil {
+				t.Fatalf("unexpected error %v", err)
+			}
+		})
+	}
+}
+
+func TestRunInitPhase(t *testing.T) {
+	phaseErr := errors.New("exit status 1")
+
+	testCases := []struct {
+		name            string
+		runTimeout      time.Duration
+		cancelRun       bool
+		phaseTimeoutMs  int64
+		phaseFunc       func(ctx context.Context) error
+		expectedError   string
+		expectedWrapped error
+	}{
+		{
+			name: "success",
+			phaseFunc: func(ctx context.Context) error {
+				return nil
+			},
+		},
+		{
+			name: "failure",
+			phaseFunc: func(ctx context.Context) error {
+				return phaseErr
+			},
+			expectedError:   "exit status 1",
+			expectedWrapped: phaseErr,
+		},
+		{
+			name:           "phase timeout",
+			phaseTimeoutMs: 10,
+			phaseFunc: func(ctx context.Context) error {
+				<-ctx.Done()
+				return errors.New("signal: killed")
+			},
+			expectedError:   "init phase \"script\" timed out after 10ms: context deadline exceeded",
+			expectedWrapped: context.DeadlineExceeded,
+		},
+		{
+			name:       "run timeout",
+			runTimeout: 10 * time.Millisecond,
+			phaseFunc: func(ctx context.Context) error {
+				<-ctx.Done()
+				return errors.New("signal: killed")
+			},
+			expectedError:   "init timed out during phase \"script\": context deadline exceeded",
+			expectedWrapped: context.DeadlineExceeded,
+		},
+		{
+			name:      "run canceled",
+			cancelRun: true,
+			phaseFunc: func(ctx context.Context) error {
+				<-ctx.Done()
+				return errors.New("signal: killed")
+			},
+			expectedError:   "init canceled during phase \"script\": context canceled",
+			expectedWrapped: context.Canceled,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			if tc.runTimeout > 0 {
+				ctx, cancel = context.WithTimeout(ctx, tc.runTimeout)
+				defer cancel()
+			}
+
+			if tc.cancelRun {
+				cancel()
+			}
+
+			req := &proto.InitRequest{
+				PhaseTimeoutsMs: map[string]int64{
+					initPhaseScript: tc.phaseTimeoutMs,
+				},
+			}
+
+			err := runInitPhase(ctx, req, initPhaseScript, tc.phaseFunc)
+
+			if len(tc.expectedError) == 0 {
+				if err != nil {
+					t.Fatalf("unexpected error %v", err)
+				}
+
+				return
+			}
+
+			if err == nil || err.Error() != tc.expectedError {
+				t.Fatalf("expected the error %q, got %v", tc.expectedError, err)
+			}
+
+			if !errors.Is(err, tc.expectedWrapped) {
+				t.Fatalf("expected the error to wrap %v", tc.expectedWrapped)
+			}
+
+			var initPhaseErr *initPhaseError
+
+			if !errors.As(err, &initPhaseErr) || initPhaseErr.phase != initPhaseScript {
+				t.Fatalf("expected an init phase error, got %T", err)
+			}
+		})
+	}
+}
+
+func TestInitCmdOutputWriter(t *testing.T) {
+	writes := []string{
+		"Cloning",
+		" repository\nDone\n",
+		"Last line without newline",
+	}
+
+	expectedLogLines := []string{
+		"Cloning repository\n",
+		"Done\n",
+		"Last line without newline",
+	}
+
+	initRunManager := initrun.NewManager(t.TempDir())
+	stderrTail := newOutputTail(2)
+
+	run, err := initRunManager.Start(
+		"yolo-sh",
+		"agent-container",
+		func(run *initrun.Run) error {
+			outputWriter := newInitCmdOutputWriter(run, stderrTail)
+
+			for _, write := range writes {
+				if _, err := outputWriter.Write([]byte(write)); err != nil {
+					return err
+				}
+			}
+
+			outputWriter.flush()
+
+			return nil
+		},
+	)
+
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	logLines := []string{}
+
+	err = run.Follow(context.Background(), 0, func(event initrun.Event) error {
+		logLines = append(logLines, event.LogLine)
+		return nil
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	if !reflect.DeepEqual(logLines, expectedLogLines) {
+		t.Fatalf("expected %q, got %q", expectedLogLines, logLines)
+	}
+
+	expectedTail := "Done\nLast line without newline"
+
+	if tail := stderrTail.String(); tail != expectedTail {
+		t.Fatalf("expected the tail %q, got %q", expectedTail, tail)
+	}
+}
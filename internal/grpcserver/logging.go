@@ -0,0 +1,112 @@
+package grpcserver
+
+import (
+	"context"
+	"time"
+
+	"github.com/yolo-sh/agent-container/internal/logging"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// The metadata key used to pass the request ID.
+// The ID sent by the agent in the host, if any, is reused
+// so that the logs of both sides could be correlated.
+const requestIDMetadataKey = "x-request-id"
+
+var logger = logging.Component(logging.ComponentGRPC)
+
+func unaryLoggingInterceptor(
+	ctx context.Context,
+	req interface{},
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (interface{}, error) {
+
+	requestID := lookupRequestID(ctx)
+	ctx = logging.ContextWithRequestID(ctx, requestID)
+
+	grpc.SetHeader(ctx, metadata.Pairs(requestIDMetadataKey, requestID))
+
+	startedAt := time.Now()
+
+	resp, err := handler(ctx, req)
+
+	logRPC(ctx, info.FullMethod, startedAt, err)
+
+	return resp, err
+}
+
+func streamLoggingInterceptor(
+	srv interface{},
+	stream grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) error {
+
+	requestID := lookupRequestID(stream.Context())
+
+	stream.SetHeader(metadata.Pairs(requestIDMetadataKey, requestID))
+
+	loggingStream := &loggingServerStream{
+		ServerStream: stream,
+		ctx:          logging.ContextWithRequestID(stream.Context(), requestID),
+	}
+
+	startedAt := time.Now()
+
+	err := handler(srv, loggingStream)
+
+	logRPC(loggingStream.ctx, info.FullMethod, startedAt, err)
+
+	return err
+}
+
+func lookupRequestID(ctx context.Context) string {
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	if requestIDs := md.Get(requestIDMetadataKey); len(requestIDs) > 0 &&
+		len(requestIDs[0]) > 0 {
+
+		return requestIDs[0]
+	}
+
+	return logging.NewRequestID()
+}
+
+func logRPC(
+	ctx context.Context,
+	method string,
+	startedAt time.Time,
+	err error,
+) {
+
+	code := status.Code(err)
+	args := []interface{}{
+		"method", method,
+		"code", code.String(),
+		"duration", time.Since(startedAt),
+	}
+
+	// Streams are canceled each time the
+	// agent in the host closes them
+	if code == codes.OK || code == codes.Canceled {
+		logger.DebugContext(ctx, "rpc finished", args...)
+		return
+	}
+
+	logger.WarnContext(ctx, "rpc failed", append(args, "error", err)...)
+}
+
+// loggingServerStream overrides the stream context
+// so that the handlers log with the request ID.
+type loggingServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *loggingServerStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,126 @@
+package grpcserver
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/yolo-sh/agent-container/internal/logging"
+	"github.com/yolo-sh/agent-container/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// StreamLogs sends the agent's own log records
+// kept in memory and, in follow mode,
+// the ones logged while the stream is open.
+func (*agentServer) StreamLogs(
+	req *proto.StreamLogsRequest,
+	stream proto.Agent_StreamLogsServer,
+) error {
+
+	filter, err := buildLogRecordsFilter(req)
+
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	var followedRecords <-chan logging.Record
+
+	if req.Follow {
+		// Subscribed before the buffered records are read
+		// so that no record is lost in between
+		records, unsubscribe := logging.SubscribeRecords()
+		defer unsubscribe()
+
+		followedRecords = records
+	}
+
+	bufferedRecords := logging.BufferedRecords(filter)
+
+	if req.Tail > 0 && int(req.Tail) < len(bufferedRecords) {
+		bufferedRecords = bufferedRecords[len(bufferedRecords)-int(req.Tail):]
+	}
+
+	var lastSentSeq uint64
+
+	for _, record := range bufferedRecords {
+		if err := sendLogRecord(stream, record); err != nil {
+			return err
+		}
+
+		lastSentSeq = record.Seq
+	}
+
+	if !req.Follow {
+		return nil
+	}
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return status.FromContextError(stream.Context().Err()).Err()
+		case record, ok := <-followedRecords:
+			if !ok {
+				return status.Error(
+					codes.ResourceExhausted,
+					"log records are produced faster than they are received",
+				)
+			}
+
+			// Already sent as a buffered record
+			if record.Seq <= lastSentSeq || !filter.Match(record) {
+				continue
+			}
+
+			if err := sendLogRecord(stream, record); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func buildLogRecordsFilter(
+	req *proto.StreamLogsRequest,
+) (logging.RecordsFilter, error) {
+
+	filter := logging.RecordsFilter{
+		MinLevel:   slog.LevelDebug,
+		Components: req.Components,
+	}
+
+	if len(req.MinLevel) > 0 {
+		minLevel, err := logging.ParseLevel(req.MinLevel)
+
+		if err != nil {
+			return filter, err
+		}
+
+		filter.MinLevel = minLevel
+	}
+
+	for _, component := range req.Components {
+		if !logging.IsKnownComponent(component) {
+			return filter, fmt.Errorf("unknown component \"%s\"", component)
+		}
+	}
+
+	return filter, nil
+}
+
+func sendLogRecord(
+	stream proto.Agent_StreamLogsServer,
+	record logging.Record,
+) error {
+
+	return stream.Send(&proto.StreamLogsReply{
+		Record: &proto.LogRecord{
+			Seq:        record.Seq,
+			TimeUnixMs: record.Time.UnixMilli(),
+			Level:      record.Level.String(),
+			Component:  record.Component,
+			Message:    record.Message,
+			RequestId:  record.RequestID,
+			Attrs:      record.Attrs,
+		},
+	})
+}
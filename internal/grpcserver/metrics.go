@@ -0,0 +1,24 @@
+package grpcserver
+
+import (
+	"context"
+
+	"github.com/yolo-sh/agent-container/internal/metrics"
+	"github.com/yolo-sh/agent-container/proto"
+)
+
+func (*agentServer) GetMetrics(
+	ctx context.Context,
+	req *proto.GetMetricsRequest,
+) (*proto.GetMetricsReply, error) {
+
+	metricsContent, err := metrics.Gather()
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &proto.GetMetricsReply{
+		Content: metricsContent,
+	}, nil
+}
@@ -0,0 +1,116 @@
+package grpcserver
+
+import (
+	"context"
+	"errors"
+	"sort"
+
+	"github.com/yolo-sh/agent-container/entities"
+	"github.com/yolo-sh/agent-container/internal/network"
+	"github.com/yolo-sh/agent-container/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (a *agentServer) ExposePort(
+	ctx context.Context,
+	req *proto.ExposePortRequest,
+) (*proto.ExposePortReply, error) {
+
+	err := a.proxyManager.ExposePort(entities.PortForwardingRule{
+		ExternalPort:      req.ExternalPort,
+		TargetNetwork:     req.TargetNetwork,
+		TargetAddr:        req.TargetAddr,
+		TLS:               req.Tls,
+		SendProxyProtocol: req.SendProxyProtocol,
+	})
+
+	if err != nil {
+		return nil, buildPortForwardingStatusErr(err)
+	}
+
+	return &proto.ExposePortReply{}, nil
+}
+
+func (a *agentServer) UnexposePort(
+	ctx context.Context,
+	req *proto.UnexposePortRequest,
+) (*proto.UnexposePortReply, error) {
+
+	err := a.proxyManager.UnexposePort(req.ExternalPort)
+
+	if err != nil {
+		return nil, buildPortForwardingStatusErr(err)
+	}
+
+	return &proto.UnexposePortReply{}, nil
+}
+
+func (a *agentServer) ListForwardedPorts(
+	ctx context.Context,
+	req *proto.ListForwardedPortsRequest,
+) (*proto.ListForwardedPortsReply, error) {
+
+	proxies := a.proxyManager.List()
+
+	sort.Slice(proxies, func(i, j int) bool {
+		return proxies[i].ListeningPort < proxies[j].ListeningPort
+	})
+
+	reply := &proto.ListForwardedPortsReply{
+		Ports:     []*proto.ForwardedPort{},
+		Listeners: []*proto.DetectedListener{},
+	}
+
+	for _, proxy := range proxies {
+		listeningAddr := ""
+
+		if len(proxy.ListeningAddrs) > 0 {
+			listeningAddr = proxy.ListeningAddrs[0]
+		}
+
+		reply.Ports = append(reply.Ports, &proto.ForwardedPort{
+			Source:            string(proxy.Source),
+			ListeningAddr:     listeningAddr,
+			ListeningAddrs:    proxy.ListeningAddrs,
+			ListeningPort:     proxy.ListeningPort,
+			TargetNetwork:     proxy.TargetNetwork,
+			TargetAddr:        proxy.TargetAddr,
+			StartedAtUnixMs:   proxy.StartedAt.UnixMilli(),
+			Tls:               proxy.TLS,
+			SendProxyProtocol: proxy.SendProxyProtocol,
+		})
+	}
+
+	for _, listener := range a.proxyManager.Listeners() {
+		detectedListener := &proto.DetectedListener{
+			Addr:                 listener.Addr,
+			Port:                 listener.Port,
+			Wildcard:             listener.Wildcard,
+			State:                string(listener.State),
+			Reason:               listener.Reason,
+			StateChangedAtUnixMs: listener.StateChangedAt.UnixMilli(),
+			Failures:             uint32(listener.Failures),
+		}
+
+		if !listener.NextRetryAt.IsZero() {
+			detectedListener.NextRetryAtUnixMs = listener.NextRetryAt.UnixMilli()
+		}
+
+		reply.Listeners = append(reply.Listeners, detectedListener)
+	}
+
+	return reply, nil
+}
+
+func buildPortForwardingStatusErr(err error) error {
+	if errors.Is(err, network.ErrInvalidPortForwardingRule) {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if errors.Is(err, network.ErrPortForwardingRuleNotFound) {
+		return status.Error(codes.NotFound, err.Error())
+	}
+
+	return err
+}
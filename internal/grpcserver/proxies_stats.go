@@ -0,0 +1,68 @@
+package grpcserver
+
+import (
+	"context"
+
+	"github.com/yolo-sh/agent-container/internal/network"
+	"github.com/yolo-sh/agent-container/proto"
+)
+
+func (a *agentServer) GetProxiesStats(
+	ctx context.Context,
+	req *proto.GetProxiesStatsRequest,
+) (*proto.GetProxiesStatsReply, error) {
+
+	requestedPorts := map[uint64]bool{}
+
+	for _, port := range req.Ports {
+		requestedPorts[port] = true
+	}
+
+	reply := &proto.GetProxiesStatsReply{
+		Ports: []*proto.ProxyPortStats{},
+	}
+
+	for _, portStats := range a.proxyManager.Stats() {
+		if len(requestedPorts) > 0 && !requestedPorts[portStats.ListeningPort] {
+			continue
+		}
+
+		reply.Ports = append(
+			reply.Ports,
+			buildProtoProxyPortStats(portStats),
+		)
+	}
+
+	return reply, nil
+}
+
+func buildProtoProxyPortStats(
+	portStats network.ProxyPortStats,
+) *proto.ProxyPortStats {
+
+	recentConns := []*proto.ProxyConnStats{}
+
+	for _, connStats := range portStats.RecentConns {
+		recentConns = append(recentConns, &proto.ProxyConnStats{
+			RemoteAddr:      connStats.RemoteAddr,
+			BytesInbound:    connStats.BytesInbound,
+			BytesOutbound:   connStats.BytesOutbound,
+			StartedAtUnixMs: connStats.StartedAt.UnixMilli(),
+			DurationMs:      connStats.Duration.Milliseconds(),
+			CloseReason:     string(connStats.CloseReason),
+			CloseError:      connStats.CloseError,
+		})
+	}
+
+	return &proto.ProxyPortStats{
+		Port:                 portStats.ListeningPort,
+		ActiveConns:          portStats.ActiveConns,
+		TotalConns:           portStats.TotalConns,
+		FailedConns:          portStats.FailedConns,
+		BytesInbound:         portStats.BytesInbound,
+		BytesOutbound:        portStats.BytesOutbound,
+		TotalConnsDurationMs: portStats.TotalConnsDuration.Milliseconds(),
+		RecentConns:          recentConns,
+		RejectedConns:        portStats.RejectedConns,
+	}
+}
@@ -0,0 +1,138 @@
+package grpcserver
+
+import (
+	"net"
+	"strconv"
+
+	"github.com/yolo-sh/agent-container/internal/tunnel"
+	"github.com/yolo-sh/agent-container/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ReverseTunnel lets the processes running in the container
+// reach a service running on the host (like "ssh -R"):
+// a loopback listener is opened in the container and
+// each accepted connection is multiplexed over the stream.
+func (a *agentServer) ReverseTunnel(
+	stream proto.Agent_ReverseTunnelServer,
+) error {
+
+	firstReq, err := stream.Recv()
+
+	if err != nil {
+		return err
+	}
+
+	registration := firstReq.Registration
+
+	if registration == nil {
+		return status.Error(
+			codes.InvalidArgument,
+			"the first ReverseTunnel request must contain a registration",
+		)
+	}
+
+	containerPort := registration.ContainerPort
+
+	if containerPort == 0 {
+		containerPort = registration.HostPort
+	}
+
+	if containerPort == 0 || containerPort > 65535 {
+		return status.Errorf(
+			codes.InvalidArgument,
+			"invalid container port %d",
+			containerPort,
+		)
+	}
+
+	// The listener must not be exposed back to the host
+	// by the auto-detected proxies
+	includePort := a.proxyManager.ExcludePort(containerPort)
+	defer includePort()
+
+	listener, err := net.Listen(
+		"tcp",
+		net.JoinHostPort(
+			"127.0.0.1",
+			strconv.FormatUint(containerPort, 10),
+		),
+	)
+
+	if err != nil {
+		return status.Error(codes.FailedPrecondition, err.Error())
+	}
+
+	err = stream.Send(&proto.ReverseTunnelReply{
+		ListeningAddr: listener.Addr().String(),
+	})
+
+	if err != nil {
+		listener.Close()
+		return err
+	}
+
+	logger.InfoContext(
+		stream.Context(),
+		"reverse tunnel opened",
+		"container_addr", listener.Addr().String(),
+		"host_port", registration.HostPort,
+	)
+
+	err = tunnel.ServeListener(
+		listener,
+		&reverseTunnelConnFrameStream{
+			stream:     stream,
+			firstFrame: firstReq.ConnFrame,
+		},
+	)
+
+	logger.InfoContext(
+		stream.Context(),
+		"reverse tunnel closed",
+		"container_addr", listener.Addr().String(),
+		"host_port", registration.HostPort,
+		"error", err,
+	)
+
+	return err
+}
+
+// reverseTunnelConnFrameStream adapts the ReverseTunnel stream
+// to the interface expected by the tunnel package.
+type reverseTunnelConnFrameStream struct {
+	stream proto.Agent_ReverseTunnelServer
+	// The first request may also carry a frame
+	firstFrame *proto.TunnelConnFrame
+}
+
+func (r *reverseTunnelConnFrameStream) SendConnFrame(
+	frame *proto.TunnelConnFrame,
+) error {
+
+	return r.stream.Send(&proto.ReverseTunnelReply{
+		ConnFrame: frame,
+	})
+}
+
+func (r *reverseTunnelConnFrameStream) RecvConnFrame() (*proto.TunnelConnFrame, error) {
+	if r.firstFrame != nil {
+		firstFrame := r.firstFrame
+		r.firstFrame = nil
+
+		return firstFrame, nil
+	}
+
+	for {
+		req, err := r.stream.Recv()
+
+		if err != nil {
+			return nil, err
+		}
+
+		if req.ConnFrame != nil {
+			return req.ConnFrame, nil
+		}
+	}
+}
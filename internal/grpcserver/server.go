@@ -5,16 +5,49 @@ import (
 	"fmt"
 	"net"
 	"os"
+	"sync/atomic"
 
+	"github.com/yolo-sh/agent-container/internal/env"
+	"github.com/yolo-sh/agent-container/internal/gitcredentials"
+	"github.com/yolo-sh/agent-container/internal/initrun"
+	"github.com/yolo-sh/agent-container/internal/network"
 	"github.com/yolo-sh/agent-container/proto"
 	"google.golang.org/grpc"
 )
 
+type ServerConfig struct {
+	// The retries of the repository access
+	// check run by "Init" before cloning
+	GitAccessCheckPolicy env.GitAccessCheckPolicy
+}
+
+func NewDefaultServerConfig() ServerConfig {
+	return ServerConfig{
+		GitAccessCheckPolicy: env.NewDefaultGitAccessCheckPolicy(),
+	}
+}
+
 type agentServer struct {
 	proto.UnimplementedAgentServer
+
+	config               ServerConfig
+	proxyManager         *network.ProxyManager
+	initRunManager       *initrun.Manager
+	gitCredentialsStore  *gitcredentials.Store
+	gitCredentialsBroker *gitcredentials.HostBroker
+	sshAgentForwarded    atomic.Bool
 }
 
-func ListenAndServe(serverAddrProtocol, serverAddr string) error {
+func ListenAndServe(
+	serverAddrProtocol string,
+	serverAddr string,
+	config ServerConfig,
+	proxyManager *network.ProxyManager,
+	initRunManager *initrun.Manager,
+	gitCredentialsStore *gitcredentials.Store,
+	gitCredentialsBroker *gitcredentials.HostBroker,
+) error {
+
 	tcpServer, err := net.Listen(serverAddrProtocol, serverAddr)
 
 	if err != nil {
@@ -27,9 +60,18 @@ func ListenAndServe(serverAddrProtocol, serverAddr string) error {
 		}
 	}
 
-	grpcServer := grpc.NewServer()
+	grpcServer := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(unaryLoggingInterceptor),
+		grpc.ChainStreamInterceptor(streamLoggingInterceptor),
+	)
 
-	proto.RegisterAgentServer(grpcServer, &agentServer{})
+	proto.RegisterAgentServer(grpcServer, &agentServer{
+		config:               config,
+		proxyManager:         proxyManager,
+		initRunManager:       initRunManager,
+		gitCredentialsStore:  gitCredentialsStore,
+		gitCredentialsBroker: gitCredentialsBroker,
+	})
 
 	return grpcServer.Serve(tcpServer)
 }
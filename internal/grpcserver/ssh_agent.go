@@ -0,0 +1,146 @@
+package grpcserver
+
+import (
+	"context"
+	"net"
+	"os"
+
+	"github.com/yolo-sh/agent-container/constants"
+	"github.com/yolo-sh/agent-container/internal/system"
+	"github.com/yolo-sh/agent-container/internal/tunnel"
+	"github.com/yolo-sh/agent-container/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ForwardSSHAgent lets the processes running in the container
+// use the SSH agent of the host (like "ssh -A"): a Unix socket,
+// only reachable by the "yolo" user, is opened in the container
+// and each accepted connection is multiplexed over the stream.
+// Only one SSH agent could be forwarded at a time.
+func (a *agentServer) ForwardSSHAgent(
+	stream proto.Agent_ForwardSSHAgentServer,
+) error {
+
+	if !a.sshAgentForwarded.CompareAndSwap(false, true) {
+		return status.Error(
+			codes.FailedPrecondition,
+			"an SSH agent is already forwarded",
+		)
+	}
+
+	defer a.sshAgentForwarded.Store(false)
+
+	socketPath := constants.SSHAgentSocketPath
+
+	if err := ensureSSHAgentSocketDir(stream.Context()); err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+
+	// Prevent "bind: address already in use" error
+	if err := os.RemoveAll(socketPath); err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+
+	// Removes the socket once closed
+	listener, err := net.Listen("unix", socketPath)
+
+	if err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+
+	if err := os.Chmod(socketPath, 0600); err != nil {
+		listener.Close()
+		return status.Error(codes.Internal, err.Error())
+	}
+
+	if err := system.ChownToYoloUser(socketPath); err != nil {
+		logger.WarnContext(
+			stream.Context(),
+			"error when setting SSH agent socket owner",
+			"error", err,
+		)
+	}
+
+	err = stream.Send(&proto.ForwardSSHAgentReply{
+		SocketPath: socketPath,
+	})
+
+	if err != nil {
+		listener.Close()
+		return err
+	}
+
+	logger.InfoContext(
+		stream.Context(),
+		"SSH agent forwarding opened",
+		"socket_path", socketPath,
+	)
+
+	err = tunnel.ServeListener(
+		listener,
+		&sshAgentConnFrameStream{
+			stream: stream,
+		},
+	)
+
+	logger.InfoContext(
+		stream.Context(),
+		"SSH agent forwarding closed",
+		"socket_path", socketPath,
+		"error", err,
+	)
+
+	return err
+}
+
+// ensureSSHAgentSocketDir creates the directory of the
+// socket, only accessible by the "yolo" user, so that the
+// socket could not be reached from the host nor by the
+// other users of the container.
+func ensureSSHAgentSocketDir(ctx context.Context) error {
+	socketDirPath := constants.SSHAgentSocketDirPath
+
+	if err := system.EnsurePrivateDir(socketDirPath); err != nil {
+		return err
+	}
+
+	if err := system.ChownToYoloUser(socketDirPath); err != nil {
+		logger.WarnContext(
+			ctx,
+			"error when setting SSH agent socket directory owner",
+			"error", err,
+		)
+	}
+
+	return nil
+}
+
+// sshAgentConnFrameStream adapts the ForwardSSHAgent stream
+// to the interface expected by the tunnel package.
+type sshAgentConnFrameStream struct {
+	stream proto.Agent_ForwardSSHAgentServer
+}
+
+func (s *sshAgentConnFrameStream) SendConnFrame(
+	frame *proto.TunnelConnFrame,
+) error {
+
+	return s.stream.Send(&proto.ForwardSSHAgentReply{
+		ConnFrame: frame,
+	})
+}
+
+func (s *sshAgentConnFrameStream) RecvConnFrame() (*proto.TunnelConnFrame, error) {
+	for {
+		req, err := s.stream.Recv()
+
+		if err != nil {
+			return nil, err
+		}
+
+		if req.ConnFrame != nil {
+			return req.ConnFrame, nil
+		}
+	}
+}
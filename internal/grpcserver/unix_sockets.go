@@ -0,0 +1,114 @@
+package grpcserver
+
+import (
+	"context"
+	"net"
+
+	"github.com/yolo-sh/agent-container/internal/tunnel"
+	"github.com/yolo-sh/agent-container/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (a *agentServer) ListUnixSockets(
+	ctx context.Context,
+	req *proto.ListUnixSocketsRequest,
+) (*proto.ListUnixSocketsReply, error) {
+
+	sockets, err := a.proxyManager.ListUnixSockets()
+
+	if err != nil {
+		return nil, err
+	}
+
+	reply := &proto.ListUnixSocketsReply{
+		Sockets: []*proto.UnixSocket{},
+	}
+
+	for _, socket := range sockets {
+		reply.Sockets = append(reply.Sockets, &proto.UnixSocket{
+			Path:  socket.Path,
+			Inode: socket.Inode,
+		})
+	}
+
+	return reply, nil
+}
+
+func (a *agentServer) DialSocket(stream proto.Agent_DialSocketServer) error {
+	firstReq, err := stream.Recv()
+
+	if err != nil {
+		return err
+	}
+
+	isListeningSocket, err := a.proxyManager.IsListeningUnixSocket(
+		firstReq.SocketPath,
+	)
+
+	if err != nil {
+		return err
+	}
+
+	if !isListeningSocket {
+		return status.Errorf(
+			codes.NotFound,
+			"no listening Unix socket found at \"%s\" in the allowed directories",
+			firstReq.SocketPath,
+		)
+	}
+
+	var dialer net.Dialer
+	socketConn, err := dialer.DialContext(
+		stream.Context(),
+		"unix",
+		firstReq.SocketPath,
+	)
+
+	if err != nil {
+		return status.Error(codes.Unavailable, err.Error())
+	}
+
+	return tunnel.Pipe(
+		socketConn,
+		&dialSocketFrameStream{
+			stream:     stream,
+			firstFrame: firstReq.Frame,
+		},
+	)
+}
+
+// dialSocketFrameStream adapts the DialSocket stream
+// to the interface expected by the tunnel package.
+type dialSocketFrameStream struct {
+	stream proto.Agent_DialSocketServer
+	// The first request may also carry data
+	firstFrame *proto.TunnelFrame
+}
+
+func (d *dialSocketFrameStream) SendFrame(frame *proto.TunnelFrame) error {
+	return d.stream.Send(&proto.DialSocketReply{
+		Frame: frame,
+	})
+}
+
+func (d *dialSocketFrameStream) RecvFrame() (*proto.TunnelFrame, error) {
+	if d.firstFrame != nil {
+		firstFrame := d.firstFrame
+		d.firstFrame = nil
+
+		return firstFrame, nil
+	}
+
+	req, err := d.stream.Recv()
+
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Frame == nil { // Nothing to forward
+		return &proto.TunnelFrame{}, nil
+	}
+
+	return req.Frame, nil
+}
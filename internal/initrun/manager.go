@@ -0,0 +1,212 @@
+package initrun
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/yolo-sh/agent-container/internal/logging"
+)
+
+var ErrInitRunNotFound = errors.New("init run not found")
+
+// The number of runs kept on disk
+const maxPersistedRuns = 10
+
+var logger = logging.Component(logging.ComponentInit)
+
+// Manager starts the Init runs and
+// loads the ones persisted by previous agents.
+type Manager struct {
+	dirPath string
+
+	mutex sync.Mutex
+	runs  map[string]*Run
+}
+
+func NewManager(dirPath string) *Manager {
+	return &Manager{
+		dirPath: dirPath,
+		runs:    map[string]*Run{},
+	}
+}
+
+// Start runs "runFunc" in the background. The run finishes
+// with the error returned by "runFunc", whatever happens
+// to the clients following it.
+func (m *Manager) Start(
+	repoOwner string,
+	repoName string,
+	runFunc func(run *Run) error,
+) (*Run, error) {
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	runID := buildRunID()
+
+	run, err := newRun(
+		filepath.Join(m.dirPath, runID),
+		Info{
+			ID:        runID,
+			Status:    StatusRunning,
+			RepoOwner: repoOwner,
+			RepoName:  repoName,
+			StartedAt: time.Now(),
+		},
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	m.runs[runID] = run
+
+	m.removeOldRuns()
+
+	go func() {
+		run.finish(runFunc(run))
+	}()
+
+	return run, nil
+}
+
+// Get returns the run with the passed ID or,
+// when empty, the most recent run.
+func (m *Manager) Get(runID string) (*Run, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if len(runID) == 0 {
+		runIDs, err := m.listPersistedRunIDs()
+
+		if err != nil {
+			return nil, err
+		}
+
+		if len(runIDs) == 0 {
+			return nil, ErrInitRunNotFound
+		}
+
+		runID = runIDs[len(runIDs)-1]
+	}
+
+	if run, ok := m.runs[runID]; ok {
+		return run, nil
+	}
+
+	// Prevent path traversal
+	if runID != filepath.Base(runID) {
+		return nil, ErrInitRunNotFound
+	}
+
+	run, err := loadRun(filepath.Join(m.dirPath, runID))
+
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrInitRunNotFound
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("error when loading init run %s: %v", runID, err)
+	}
+
+	m.runs[runID] = run
+
+	return run, nil
+}
+
+// Running returns the run started by this
+// manager that is still running, if any.
+func (m *Manager) Running() *Run {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	var runningRun *Run
+
+	for _, run := range m.runs {
+		if run.Info().Status != StatusRunning {
+			continue
+		}
+
+		// The most recent one
+		if runningRun == nil || run.ID() > runningRun.ID() {
+			runningRun = run
+		}
+	}
+
+	return runningRun
+}
+
+// Must be called with the mutex held
+func (m *Manager) removeOldRuns() {
+	runIDs, err := m.listPersistedRunIDs()
+
+	if err != nil {
+		logger.Warn("error when listing init runs", "error", err)
+		return
+	}
+
+	for len(runIDs) > maxPersistedRuns {
+		runID := runIDs[0]
+		runIDs = runIDs[1:]
+
+		if run, ok := m.runs[runID]; ok && run.Info().Status == StatusRunning {
+			continue
+		}
+
+		delete(m.runs, runID)
+
+		if err := os.RemoveAll(filepath.Join(m.dirPath, runID)); err != nil {
+			logger.Warn(
+				"error when removing init run",
+				"run_id", runID,
+				"error", err,
+			)
+		}
+	}
+}
+
+// Oldest first
+func (m *Manager) listPersistedRunIDs() ([]string, error) {
+	entries, err := os.ReadDir(m.dirPath)
+
+	if errors.Is(err, os.ErrNotExist) {
+		return []string{}, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	runIDs := []string{}
+
+	for _, entry := range entries {
+		if entry.IsDir() {
+			runIDs = append(runIDs, entry.Name())
+		}
+	}
+
+	// The run IDs start with their start time
+	sort.Strings(runIDs)
+
+	return runIDs, nil
+}
+
+func buildRunID() string {
+	randomSuffix := make([]byte, 4)
+
+	// Never returns an error on Linux
+	rand.Read(randomSuffix)
+
+	return fmt.Sprintf(
+		"%s-%s",
+		time.Now().UTC().Format("20060102T150405.000Z"),
+		hex.EncodeToString(randomSuffix),
+	)
+}
@@ -0,0 +1,421 @@
+package initrun
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	spb "google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+const (
+	infoFileName   = "run.json"
+	eventsFileName = "events.jsonl"
+)
+
+type Status string
+
+const (
+	StatusRunning   Status = "running"
+	StatusSucceeded Status = "succeeded"
+	StatusFailed    Status = "failed"
+	StatusCanceled  Status = "canceled"
+	// The agent has exited during the run
+	StatusInterrupted Status = "interrupted"
+)
+
+// Event is an output of an Init run (a log line
+// or the generated public keys), persisted so that
+// it could be replayed (see "Run.Follow").
+type Event struct {
+	// The index of the event in the run
+	Offset uint64    `json:"offset"`
+	Time   time.Time `json:"time"`
+
+	LogLineHeader             string  `json:"log_line_header,omitempty"`
+	LogLine                   string  `json:"log_line,omitempty"`
+	GitHubSSHPublicKeyContent *string `json:"github_ssh_public_key_content,omitempty"`
+	GitHubGPGPublicKeyContent *string `json:"github_gpg_public_key_content,omitempty"`
+}
+
+// Info describes an Init run
+type Info struct {
+	ID         string    `json:"id"`
+	Status     Status    `json:"status"`
+	RepoOwner  string    `json:"repo_owner"`
+	RepoName   string    `json:"repo_name"`
+	StartedAt  time.Time `json:"started_at"`
+	FinishedAt time.Time `json:"finished_at"`
+	Error      string    `json:"error,omitempty"`
+	// The gRPC status (code, message and details)
+	// the run has failed with, when the error is
+	// a status, so that it could be rebuilt once loaded
+	ErrorStatus json.RawMessage `json:"error_status,omitempty"`
+	// Not persisted in the info file
+	EventsCount uint64 `json:"-"`
+}
+
+// Run is an Init run. Its events and info are persisted
+// in its own directory, as they happen.
+type Run struct {
+	dirPath string
+
+	mutex      sync.Mutex
+	info       Info
+	err        error
+	events     []Event
+	eventsFile *os.File
+	// Used to log only the first persistence error
+	persistFailed bool
+	// Closed (and replaced) each time
+	// an event is appended or the run finishes
+	changedChan chan struct{}
+}
+
+func newRun(dirPath string, info Info) (*Run, error) {
+	// Make sure that the runs could be read by the agent in the host
+	err := os.MkdirAll(dirPath, 0770)
+
+	if err != nil {
+		return nil, err
+	}
+
+	eventsFilePath := filepath.Join(dirPath, eventsFileName)
+
+	eventsFile, err := os.OpenFile(
+		eventsFilePath,
+		os.O_CREATE|os.O_WRONLY|os.O_TRUNC,
+		0660,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	// Overwrite umask.
+	// See: https://stackoverflow.com/questions/50257981/ioutils-writefile-not-respecting-permissions
+	if err := eventsFile.Chmod(0660); err != nil {
+		eventsFile.Close()
+		return nil, err
+	}
+
+	run := &Run{
+		dirPath:     dirPath,
+		info:        info,
+		events:      []Event{},
+		eventsFile:  eventsFile,
+		changedChan: make(chan struct{}),
+	}
+
+	if err := saveInfoAsFile(dirPath, info); err != nil {
+		eventsFile.Close()
+		return nil, err
+	}
+
+	return run, nil
+}
+
+// loadRun loads a finished run. A run that was still
+// running is marked as interrupted given that
+// only the current agent process could run it.
+func loadRun(dirPath string) (*Run, error) {
+	infoFileContent, err := os.ReadFile(filepath.Join(dirPath, infoFileName))
+
+	if err != nil {
+		return nil, err
+	}
+
+	var info Info
+
+	if err := json.Unmarshal(infoFileContent, &info); err != nil {
+		return nil, err
+	}
+
+	events, err := loadEvents(filepath.Join(dirPath, eventsFileName))
+
+	if err != nil {
+		return nil, err
+	}
+
+	if info.Status == StatusRunning {
+		info.Status = StatusInterrupted
+		info.Error = "the container agent has exited during the run"
+		info.ErrorStatus, err = marshalErrorStatus(
+			status.New(codes.Aborted, info.Error),
+		)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if err := saveInfoAsFile(dirPath, info); err != nil {
+			return nil, err
+		}
+	}
+
+	run := &Run{
+		dirPath:     dirPath,
+		info:        info,
+		events:      events,
+		changedChan: make(chan struct{}),
+	}
+
+	if len(info.Error) > 0 {
+		run.err = errors.New(info.Error)
+	}
+
+	if len(info.ErrorStatus) > 0 {
+		runStatus, err := unmarshalErrorStatus(info.ErrorStatus)
+
+		if err != nil {
+			return nil, err
+		}
+
+		run.err = runStatus.Err()
+	}
+
+	return run, nil
+}
+
+func marshalErrorStatus(errStatus *status.Status) (json.RawMessage, error) {
+	return protojson.Marshal(errStatus.Proto())
+}
+
+func unmarshalErrorStatus(errStatusJSON json.RawMessage) (*status.Status, error) {
+	var errStatusProto spb.Status
+
+	if err := protojson.Unmarshal(errStatusJSON, &errStatusProto); err != nil {
+		return nil, err
+	}
+
+	return status.FromProto(&errStatusProto), nil
+}
+
+func loadEvents(eventsFilePath string) ([]Event, error) {
+	eventsFile, err := os.Open(eventsFilePath)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer eventsFile.Close()
+
+	events := []Event{}
+	decoder := json.NewDecoder(eventsFile)
+
+	for decoder.More() {
+		var event Event
+
+		// The last event could have been partially
+		// written if the agent has exited during the run
+		if err := decoder.Decode(&event); err != nil {
+			break
+		}
+
+		events = append(events, event)
+	}
+
+	return events, nil
+}
+
+func (r *Run) ID() string {
+	return r.info.ID
+}
+
+func (r *Run) Info() Info {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	info := r.info
+	info.EventsCount = uint64(len(r.events))
+
+	return info
+}
+
+// Err returns the error the run has finished with, if any
+func (r *Run) Err() error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	return r.err
+}
+
+// Append adds an event to the run. The event is kept in memory
+// even if it could not be persisted so that the run is not aborted.
+func (r *Run) Append(event Event) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	event.Offset = uint64(len(r.events))
+	event.Time = time.Now()
+
+	r.events = append(r.events, event)
+
+	if r.eventsFile != nil {
+		r.persistEvent(event)
+	}
+
+	r.notifyChanged()
+}
+
+// Must be called with the mutex held
+func (r *Run) persistEvent(event Event) {
+	eventAsJSON, err := json.Marshal(event)
+
+	if err == nil {
+		_, err = r.eventsFile.Write(append(eventAsJSON, '\n'))
+	}
+
+	if err != nil && !r.persistFailed {
+		r.persistFailed = true
+
+		logger.Warn(
+			"error when persisting init run event",
+			"run_id", r.info.ID,
+			"error", err,
+		)
+	}
+}
+
+func (r *Run) finish(runErr error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.err = runErr
+	r.info.Status = StatusSucceeded
+	r.info.FinishedAt = time.Now()
+
+	if runErr != nil {
+		r.info.Status = StatusFailed
+		r.info.Error = runErr.Error()
+	}
+
+	// Persisted so that the run could be
+	// reported with the same status once loaded
+	if runStatus, ok := status.FromError(runErr); ok && runErr != nil {
+		r.info.Error = runStatus.Message()
+		errorStatus, err := marshalErrorStatus(runStatus)
+
+		if err != nil {
+			logger.Warn(
+				"error when encoding init run error status",
+				"run_id", r.info.ID,
+				"error", err,
+			)
+		}
+
+		r.info.ErrorStatus = errorStatus
+	}
+
+	if errors.Is(runErr, context.Canceled) ||
+		(runErr != nil && status.Code(runErr) == codes.Canceled) {
+
+		r.info.Status = StatusCanceled
+	}
+
+	if err := r.eventsFile.Close(); err != nil {
+		logger.Warn(
+			"error when closing init run events file",
+			"run_id", r.info.ID,
+			"error", err,
+		)
+	}
+
+	r.eventsFile = nil
+
+	if err := saveInfoAsFile(r.dirPath, r.info); err != nil {
+		logger.Warn(
+			"error when persisting init run info",
+			"run_id", r.info.ID,
+			"error", err,
+		)
+	}
+
+	r.notifyChanged()
+}
+
+// Must be called with the mutex held
+func (r *Run) notifyChanged() {
+	close(r.changedChan)
+	r.changedChan = make(chan struct{})
+}
+
+// Follow passes the events to "send", starting at "offset",
+// until the run has finished (and all its events have been sent),
+// "send" returns an error or the context is done.
+func (r *Run) Follow(
+	ctx context.Context,
+	offset uint64,
+	send func(Event) error,
+) error {
+
+	for {
+		r.mutex.Lock()
+
+		var events []Event
+
+		if offset < uint64(len(r.events)) {
+			events = r.events[offset:]
+		}
+
+		finished := r.info.Status != StatusRunning
+		changedChan := r.changedChan
+
+		r.mutex.Unlock()
+
+		for _, event := range events {
+			if err := send(event); err != nil {
+				return err
+			}
+
+			offset++
+		}
+
+		if finished {
+			return nil
+		}
+
+		// Nothing new
+		if len(events) == 0 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-changedChan:
+			}
+		}
+	}
+}
+
+func saveInfoAsFile(dirPath string, info Info) error {
+	infoAsJSON, err := json.Marshal(info)
+
+	if err != nil {
+		return err
+	}
+
+	infoFilePath := filepath.Join(dirPath, infoFileName)
+
+	err = os.WriteFile(
+		infoFilePath,
+		infoAsJSON,
+		os.FileMode(0660),
+	)
+
+	if err != nil {
+		return err
+	}
+
+	// Overwrite umask.
+	// See: https://stackoverflow.com/questions/50257981/ioutils-writefile-not-respecting-permissions
+	return os.Chmod(
+		infoFilePath,
+		0660,
+	)
+}
@@ -0,0 +1,313 @@
+package initrun
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestRunPersistence(t *testing.T) {
+	statusWithDetails, err := status.New(
+		codes.FailedPrecondition,
+		"repository not found",
+	).WithDetails(&errdetails.ErrorInfo{
+		Reason: "GIT_REPO_NOT_FOUND",
+		Domain: "yolo.sh",
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		name            string
+		runErr          error
+		expectedStatus  Status
+		expectedError   string
+		expectedCode    codes.Code
+		expectedDetails int
+	}{
+		{
+			name:           "succeeded",
+			runErr:         nil,
+			expectedStatus: StatusSucceeded,
+			expectedCode:   codes.OK,
+		},
+		{
+			name:           "failed with an error",
+			runErr:         errors.New("init script failed"),
+			expectedStatus: StatusFailed,
+			expectedError:  "init script failed",
+			expectedCode:   codes.Unknown,
+		},
+		{
+			name:            "failed with a status",
+			runErr:          statusWithDetails.Err(),
+			expectedStatus:  StatusFailed,
+			expectedError:   "repository not found",
+			expectedCode:    codes.FailedPrecondition,
+			expectedDetails: 1,
+		},
+		{
+			name:           "canceled context",
+			runErr:         fmt.Errorf("error when cloning: %w", context.Canceled),
+			expectedStatus: StatusCanceled,
+			expectedError:  "error when cloning: context canceled",
+			expectedCode:   codes.Unknown,
+		},
+		{
+			name:           "canceled status",
+			runErr:         status.Error(codes.Canceled, "canceled by the client"),
+			expectedStatus: StatusCanceled,
+			expectedError:  "canceled by the client",
+			expectedCode:   codes.Canceled,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			dirPath := t.TempDir()
+
+			run, err := NewManager(dirPath).Start(
+				"yolo-sh",
+				"agent-container",
+				func(run *Run) error {
+					run.Append(Event{LogLine: "a"})
+					run.Append(Event{LogLine: "b"})
+
+					return tc.runErr
+				},
+			)
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// Wait for the run to finish
+			if err := run.Follow(context.Background(), 0, func(Event) error { return nil }); err != nil {
+				t.Fatal(err)
+			}
+
+			// As if the agent had restarted
+			loadedRun, err := NewManager(dirPath).Get(run.ID())
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			info := loadedRun.Info()
+
+			if info.Status != tc.expectedStatus {
+				t.Fatalf("expected status %q, got %q", tc.expectedStatus, info.Status)
+			}
+
+			if info.Error != tc.expectedError {
+				t.Fatalf("expected error %q, got %q", tc.expectedError, info.Error)
+			}
+
+			if info.RepoOwner != "yolo-sh" || info.RepoName != "agent-container" {
+				t.Fatalf("unexpected repository %s/%s", info.RepoOwner, info.RepoName)
+			}
+
+			runStatus := status.Convert(loadedRun.Err())
+
+			if runStatus.Code() != tc.expectedCode {
+				t.Fatalf("expected code %s, got %s", tc.expectedCode, runStatus.Code())
+			}
+
+			if len(runStatus.Details()) != tc.expectedDetails {
+				t.Fatalf("expected %d details, got %v", tc.expectedDetails, runStatus.Details())
+			}
+
+			expectedLogLines := []string{"a", "b"}
+
+			if logLines := followLogLines(t, loadedRun); !reflect.DeepEqual(logLines, expectedLogLines) {
+				t.Fatalf("expected log lines %v, got %v", expectedLogLines, logLines)
+			}
+		})
+	}
+}
+
+func TestLoadRun(t *testing.T) {
+	testCases := []struct {
+		name             string
+		infoFileContent  string
+		eventsContent    string
+		expectedStatus   Status
+		expectedCode     codes.Code
+		expectedLogLines []string
+	}{
+		{
+			name:             "running run is interrupted",
+			infoFileContent:  `{"id":"1","status":"running"}`,
+			eventsContent:    "{\"offset\":0,\"log_line\":\"a\"}\n",
+			expectedStatus:   StatusInterrupted,
+			expectedCode:     codes.Aborted,
+			expectedLogLines: []string{"a"},
+		},
+		{
+			name:             "partially written event",
+			infoFileContent:  `{"id":"1","status":"running"}`,
+			eventsContent:    "{\"offset\":0,\"log_line\":\"a\"}\n{\"offset\":1,\"lo",
+			expectedStatus:   StatusInterrupted,
+			expectedCode:     codes.Aborted,
+			expectedLogLines: []string{"a"},
+		},
+		{
+			name:             "error without status",
+			infoFileContent:  `{"id":"1","status":"failed","error":"init script failed"}`,
+			expectedStatus:   StatusFailed,
+			expectedCode:     codes.Unknown,
+			expectedLogLines: []string{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			dirPath := t.TempDir()
+
+			writeTestFile(t, filepath.Join(dirPath, infoFileName), tc.infoFileContent)
+			writeTestFile(t, filepath.Join(dirPath, eventsFileName), tc.eventsContent)
+
+			run, err := loadRun(dirPath)
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if run.Info().Status != tc.expectedStatus {
+				t.Fatalf("expected status %q, got %q", tc.expectedStatus, run.Info().Status)
+			}
+
+			if code := status.Code(run.Err()); code != tc.expectedCode {
+				t.Fatalf("expected code %s, got %s", tc.expectedCode, code)
+			}
+
+			if logLines := followLogLines(t, run); !reflect.DeepEqual(logLines, tc.expectedLogLines) {
+				t.Fatalf("expected log lines %v, got %v", tc.expectedLogLines, logLines)
+			}
+
+			// The interruption is persisted
+			reloadedRun, err := loadRun(dirPath)
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if reloadedRun.Info().Status != tc.expectedStatus {
+				t.Fatalf("expected status %q once reloaded, got %q", tc.expectedStatus, reloadedRun.Info().Status)
+			}
+
+			if code := status.Code(reloadedRun.Err()); code != tc.expectedCode {
+				t.Fatalf("expected code %s once reloaded, got %s", tc.expectedCode, code)
+			}
+		})
+	}
+}
+
+func TestManagerGet(t *testing.T) {
+	dirPath := t.TempDir()
+	manager := NewManager(dirPath)
+
+	if _, err := manager.Get(""); !errors.Is(err, ErrInitRunNotFound) {
+		t.Fatalf("expected %v, got %v", ErrInitRunNotFound, err)
+	}
+
+	// Not a run
+	writeTestFile(t, filepath.Join(dirPath, "secret.json"), "{}")
+
+	testCases := []struct {
+		name          string
+		runID         string
+		expectedRunID string
+		expectedError error
+	}{
+		{
+			name:          "most recent",
+			runID:         "",
+			expectedRunID: "20240102T000000.000Z-b",
+		},
+		{
+			name:          "by ID",
+			runID:         "20240101T000000.000Z-a",
+			expectedRunID: "20240101T000000.000Z-a",
+		},
+		{
+			name:          "unknown",
+			runID:         "20240103T000000.000Z-c",
+			expectedError: ErrInitRunNotFound,
+		},
+		{
+			name:          "path traversal",
+			runID:         "../" + filepath.Base(dirPath),
+			expectedError: ErrInitRunNotFound,
+		},
+	}
+
+	for _, runID := range []string{"20240101T000000.000Z-a", "20240102T000000.000Z-b"} {
+		runDirPath := filepath.Join(dirPath, runID)
+
+		if err := os.MkdirAll(runDirPath, 0770); err != nil {
+			t.Fatal(err)
+		}
+
+		writeTestFile(
+			t,
+			filepath.Join(runDirPath, infoFileName),
+			fmt.Sprintf(`{"id":%q,"status":"succeeded"}`, runID),
+		)
+
+		writeTestFile(t, filepath.Join(runDirPath, eventsFileName), "")
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			run, err := manager.Get(tc.runID)
+
+			if !errors.Is(err, tc.expectedError) {
+				t.Fatalf("expected error %v, got %v", tc.expectedError, err)
+			}
+
+			if err != nil {
+				return
+			}
+
+			if run.ID() != tc.expectedRunID {
+				t.Fatalf("expected run %q, got %q", tc.expectedRunID, run.ID())
+			}
+		})
+	}
+}
+
+func followLogLines(t *testing.T, run *Run) []string {
+	t.Helper()
+
+	logLines := []string{}
+
+	err := run.Follow(context.Background(), 0, func(event Event) error {
+		logLines = append(logLines, event.LogLine)
+		return nil
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return logLines
+}
+
+func writeTestFile(t *testing.T, filePath, content string) {
+	t.Helper()
+
+	if err := os.WriteFile(filePath, []byte(content), 0660); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,308 @@
+package logging
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+	"sync/atomic"
+)
+
+// The components the log records are attributed to
+// (see the "component" attribute).
+const (
+	ComponentMain           = "main"
+	ComponentGRPC           = "grpc"
+	ComponentNetwork        = "network"
+	ComponentEnv            = "env"
+	ComponentInit           = "init"
+	ComponentGitCredentials = "git_credentials"
+)
+
+var components = []string{
+	ComponentMain,
+	ComponentGRPC,
+	ComponentNetwork,
+	ComponentEnv,
+	ComponentInit,
+	ComponentGitCredentials,
+}
+
+func IsKnownComponent(component string) bool {
+	for _, knownComponent := range components {
+		if component == knownComponent {
+			return true
+		}
+	}
+
+	return false
+}
+
+const (
+	FormatText = "text"
+	FormatJSON = "json"
+)
+
+type Config struct {
+	// "debug", "info", "warn" or "error"
+	Level string
+	// "text" or "json"
+	Format string
+	// The file the logs are also written to.
+	// Disabled when empty.
+	FilePath string
+	// The size (in bytes) above which the log file is rotated
+	FileMaxSize int64
+	// The number of rotated log files kept
+	FileMaxBackups int
+	// The number of recent records kept in memory
+	// (see "BufferedRecords")
+	BufferSize int
+}
+
+// The handler used by the component loggers.
+// Replaced by "Setup" so that the loggers created
+// before (eg: in package-level variables) are configured too.
+var currentHandler atomic.Pointer[slog.Handler]
+
+// Replaced by "Setup"
+var currentRecordsBuffer atomic.Pointer[recordsBuffer]
+
+func init() {
+	var handler slog.Handler = slog.NewTextHandler(os.Stderr, nil)
+	currentHandler.Store(&handler)
+
+	currentRecordsBuffer.Store(newRecordsBuffer(0))
+}
+
+// Setup configures the component loggers and the default
+// "log/slog" and "log" loggers. The returned closer
+// closes the log file, if any.
+func Setup(config Config) (io.Closer, error) {
+	level, err := ParseLevel(config.Level)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var output io.Writer = os.Stderr
+	var closer io.Closer = nopCloser{}
+
+	if len(config.FilePath) > 0 {
+		logFile, err := newRotatingFile(
+			config.FilePath,
+			config.FileMaxSize,
+			config.FileMaxBackups,
+		)
+
+		if err != nil {
+			return nil, err
+		}
+
+		output = io.MultiWriter(os.Stderr, logFile)
+		closer = logFile
+	}
+
+	handlerOpts := &slog.HandlerOptions{
+		Level: level,
+	}
+
+	var outputHandler slog.Handler
+
+	switch config.Format {
+	case FormatJSON:
+		outputHandler = slog.NewJSONHandler(output, handlerOpts)
+	case FormatText, "":
+		outputHandler = slog.NewTextHandler(output, handlerOpts)
+	default:
+		closer.Close()
+		return nil, fmt.Errorf("unknown log format \"%s\"", config.Format)
+	}
+
+	recordsBuffer := newRecordsBuffer(config.BufferSize)
+
+	var handler slog.Handler = &multiHandler{
+		handlers: []slog.Handler{
+			outputHandler,
+			&recordsBufferHandler{
+				buffer: recordsBuffer,
+				level:  level,
+			},
+		},
+	}
+
+	currentRecordsBuffer.Store(recordsBuffer)
+	currentHandler.Store(&handler)
+
+	// Also used by the "log" package
+	slog.SetDefault(Component(ComponentMain))
+
+	return closer, nil
+}
+
+func ParseLevel(level string) (slog.Level, error) {
+	var parsedLevel slog.Level
+
+	if len(level) == 0 {
+		return slog.LevelInfo, nil
+	}
+
+	err := parsedLevel.UnmarshalText([]byte(strings.ToUpper(level)))
+
+	if err != nil {
+		return parsedLevel, fmt.Errorf("unknown log level \"%s\"", level)
+	}
+
+	return parsedLevel, nil
+}
+
+// Component returns a logger that attributes
+// its records to the passed component.
+func Component(component string) *slog.Logger {
+	return slog.New(&componentHandler{}).With("component", component)
+}
+
+type requestIDContextKey struct{}
+
+// ContextWithRequestID attaches a request ID to the context.
+// The records logged with this context (eg: "logger.InfoContext")
+// get a "request_id" attribute.
+func ContextWithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDContextKey{}, requestID)
+}
+
+func RequestIDFromContext(ctx context.Context) string {
+	requestID, _ := ctx.Value(requestIDContextKey{}).(string)
+	return requestID
+}
+
+func NewRequestID() string {
+	requestID := make([]byte, 8)
+
+	// Never returns an error on Linux
+	rand.Read(requestID)
+
+	return hex.EncodeToString(requestID)
+}
+
+// componentHandler forwards the records to the current handler
+// (see "Setup"). The attributes and groups added by "With"
+// and "WithGroup" are replayed on each record.
+type componentHandler struct {
+	ops []func(slog.Handler) slog.Handler
+}
+
+func (h *componentHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return (*currentHandler.Load()).Enabled(ctx, level)
+}
+
+func (h *componentHandler) Handle(ctx context.Context, record slog.Record) error {
+	handler := *currentHandler.Load()
+
+	// Added before the groups, if any
+	if requestID := RequestIDFromContext(ctx); len(requestID) > 0 {
+		handler = handler.WithAttrs([]slog.Attr{
+			slog.String("request_id", requestID),
+		})
+	}
+
+	for _, op := range h.ops {
+		handler = op(handler)
+	}
+
+	return handler.Handle(ctx, record)
+}
+
+func (h *componentHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return h.withOp(func(handler slog.Handler) slog.Handler {
+		return handler.WithAttrs(attrs)
+	})
+}
+
+func (h *componentHandler) WithGroup(name string) slog.Handler {
+	return h.withOp(func(handler slog.Handler) slog.Handler {
+		return handler.WithGroup(name)
+	})
+}
+
+func (h *componentHandler) withOp(
+	op func(slog.Handler) slog.Handler,
+) slog.Handler {
+
+	ops := make([]func(slog.Handler) slog.Handler, 0, len(h.ops)+1)
+	ops = append(ops, h.ops...)
+	ops = append(ops, op)
+
+	return &componentHandler{
+		ops: ops,
+	}
+}
+
+type nopCloser struct{}
+
+func (nopCloser) Close() error {
+	return nil
+}
+
+// multiHandler passes the records to
+// all the handlers that are enabled for them
+type multiHandler struct {
+	handlers []slog.Handler
+}
+
+func (h *multiHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	for _, handler := range h.handlers {
+		if handler.Enabled(ctx, level) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (h *multiHandler) Handle(ctx context.Context, record slog.Record) error {
+	var firstErr error
+
+	for _, handler := range h.handlers {
+		if !handler.Enabled(ctx, record.Level) {
+			continue
+		}
+
+		// The record attributes must not be shared
+		err := handler.Handle(ctx, record.Clone())
+
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	return firstErr
+}
+
+func (h *multiHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	handlers := make([]slog.Handler, 0, len(h.handlers))
+
+	for _, handler := range h.handlers {
+		handlers = append(handlers, handler.WithAttrs(attrs))
+	}
+
+	return &multiHandler{
+		handlers: handlers,
+	}
+}
+
+func (h *multiHandler) WithGroup(name string) slog.Handler {
+	handlers := make([]slog.Handler, 0, len(h.handlers))
+
+	for _, handler := range h.handlers {
+		handlers = append(handlers, handler.WithGroup(name))
+	}
+
+	return &multiHandler{
+		handlers: handlers,
+	}
+}
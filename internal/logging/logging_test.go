@@ -0,0 +1,172 @@
+package logging
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParseLevel(t *testing.T) {
+	testCases := []struct {
+		name          string
+		level         string
+		expectedLevel slog.Level
+		expectedError bool
+	}{
+		{
+			name:          "default",
+			level:         "",
+			expectedLevel: slog.LevelInfo,
+		},
+		{
+			name:          "debug",
+			level:         "debug",
+			expectedLevel: slog.LevelDebug,
+		},
+		{
+			name:          "upper case",
+			level:         "WARN",
+			expectedLevel: slog.LevelWarn,
+		},
+		{
+			name:          "unknown",
+			level:         "verbose",
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			level, err := ParseLevel(tc.level)
+
+			if tc.expectedError {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error %v", err)
+			}
+
+			if level != tc.expectedLevel {
+				t.Fatalf("expected %s, got %s", tc.expectedLevel, level)
+			}
+		})
+	}
+}
+
+func TestIsKnownComponent(t *testing.T) {
+	testCases := []struct {
+		name          string
+		component     string
+		expectedKnown bool
+	}{
+		{
+			name:          "env",
+			component:     ComponentEnv,
+			expectedKnown: true,
+		},
+		{
+			name:          "git credentials",
+			component:     ComponentGitCredentials,
+			expectedKnown: true,
+		},
+		{
+			name:      "unknown",
+			component: "proxy",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			known := IsKnownComponent(tc.component)
+
+			if known != tc.expectedKnown {
+				t.Fatalf("expected %t, got %t", tc.expectedKnown, known)
+			}
+		})
+	}
+}
+
+func TestSetup(t *testing.T) {
+	previousHandler := currentHandler.Load()
+	previousDefaultLogger := slog.Default()
+
+	t.Cleanup(func() {
+		currentHandler.Store(previousHandler)
+		slog.SetDefault(previousDefaultLogger)
+	})
+
+	logFilePath := filepath.Join(t.TempDir(), "logs", "agent-container.log")
+
+	// Created before "Setup", like the package-level loggers
+	logger := Component(ComponentNetwork)
+
+	closer, err := Setup(Config{
+		Level:    "info",
+		Format:   FormatJSON,
+		FilePath: logFilePath,
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	ctx := ContextWithRequestID(context.Background(), "d4f1c2a3b4e5f607")
+
+	logger.DebugContext(ctx, "filtered")
+	logger.With("port", 3000).InfoContext(ctx, "proxy started")
+
+	if err := closer.Close(); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	logFileContent, err := os.ReadFile(logFilePath)
+
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	logLines := strings.Split(strings.TrimSpace(string(logFileContent)), "\n")
+
+	if len(logLines) != 1 {
+		t.Fatalf("expected one record, got %q", logFileContent)
+	}
+
+	var record map[string]interface{}
+
+	if err := json.Unmarshal([]byte(logLines[0]), &record); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	expectedAttrs := map[string]interface{}{
+		"level":      "INFO",
+		"msg":        "proxy started",
+		"component":  ComponentNetwork,
+		"request_id": "d4f1c2a3b4e5f607",
+		"port":       float64(3000),
+	}
+
+	for key, expectedValue := range expectedAttrs {
+		if record[key] != expectedValue {
+			t.Fatalf("expected %s=%v, got %v", key, expectedValue, record[key])
+		}
+	}
+}
+
+func TestSetupUnknownFormat(t *testing.T) {
+	_, err := Setup(Config{
+		Format: "xml",
+	})
+
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+}
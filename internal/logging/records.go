@@ -0,0 +1,254 @@
+package logging
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// The number of records a subscriber could lag
+// behind before being unsubscribed
+const recordsSubscriberBufferSize = 1024
+
+// Record is a log record kept in memory
+// so that it could be streamed to the host
+// (see "BufferedRecords" and "SubscribeRecords").
+type Record struct {
+	// Increases by one for each record
+	Seq       uint64
+	Time      time.Time
+	Level     slog.Level
+	Component string
+	Message   string
+	RequestID string
+	// The other attributes, formatted.
+	// The attributes in groups are prefixed
+	// with the group name (eg: "group.key").
+	Attrs map[string]string
+}
+
+// RecordsFilter returns the records with
+// a level greater than or equal to "MinLevel"
+// and attributed to one of "Components" (all when empty).
+type RecordsFilter struct {
+	MinLevel   slog.Level
+	Components []string
+}
+
+func (f RecordsFilter) Match(record Record) bool {
+	if record.Level < f.MinLevel {
+		return false
+	}
+
+	if len(f.Components) == 0 {
+		return true
+	}
+
+	for _, component := range f.Components {
+		if record.Component == component {
+			return true
+		}
+	}
+
+	return false
+}
+
+// recordsBuffer is a ring buffer of the most recent records
+type recordsBuffer struct {
+	mutex       sync.Mutex
+	records     []Record
+	size        int
+	next        int
+	lastSeq     uint64
+	subscribers map[chan Record]struct{}
+}
+
+func newRecordsBuffer(size int) *recordsBuffer {
+	return &recordsBuffer{
+		records:     make([]Record, 0, size),
+		size:        size,
+		subscribers: map[chan Record]struct{}{},
+	}
+}
+
+func (b *recordsBuffer) add(record Record) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.lastSeq++
+	record.Seq = b.lastSeq
+
+	if b.size > 0 {
+		if len(b.records) < b.size {
+			b.records = append(b.records, record)
+		} else {
+			b.records[b.next] = record
+		}
+
+		b.next = (b.next + 1) % b.size
+	}
+
+	for subscriberChan := range b.subscribers {
+		select {
+		case subscriberChan <- record:
+		default:
+			// Slow subscriber, the records would be lost
+			delete(b.subscribers, subscriberChan)
+			close(subscriberChan)
+		}
+	}
+}
+
+// Oldest first
+func (b *recordsBuffer) snapshot() []Record {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	records := make([]Record, 0, len(b.records))
+
+	if len(b.records) < b.size {
+		return append(records, b.records...)
+	}
+
+	records = append(records, b.records[b.next:]...)
+	return append(records, b.records[:b.next]...)
+}
+
+func (b *recordsBuffer) subscribe() (<-chan Record, func()) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	subscriberChan := make(chan Record, recordsSubscriberBufferSize)
+	b.subscribers[subscriberChan] = struct{}{}
+
+	unsubscribe := func() {
+		b.mutex.Lock()
+		defer b.mutex.Unlock()
+
+		if _, ok := b.subscribers[subscriberChan]; ok {
+			delete(b.subscribers, subscriberChan)
+			close(subscriberChan)
+		}
+	}
+
+	return subscriberChan, unsubscribe
+}
+
+// BufferedRecords returns the most recent
+// records matching the filter, oldest first.
+func BufferedRecords(filter RecordsFilter) []Record {
+	records := []Record{}
+
+	for _, record := range currentRecordsBuffer.Load().snapshot() {
+		if filter.Match(record) {
+			records = append(records, record)
+		}
+	}
+
+	return records
+}
+
+// SubscribeRecords returns a channel that receives the records
+// logged from now on. The channel is closed when the subscriber
+// lags too far behind or once "unsubscribe" has been called.
+func SubscribeRecords() (records <-chan Record, unsubscribe func()) {
+	return currentRecordsBuffer.Load().subscribe()
+}
+
+// recordsBufferHandler adds the records to the records buffer
+type recordsBufferHandler struct {
+	buffer *recordsBuffer
+	level  slog.Leveler
+	attrs  []slog.Attr
+	groups []string
+}
+
+func (h *recordsBufferHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return level >= h.level.Level()
+}
+
+func (h *recordsBufferHandler) Handle(_ context.Context, record slog.Record) error {
+	bufferedRecord := Record{
+		Time:    record.Time,
+		Level:   record.Level,
+		Message: record.Message,
+		Attrs:   map[string]string{},
+	}
+
+	for _, attr := range h.attrs {
+		addRecordAttr(&bufferedRecord, "", attr)
+	}
+
+	groupPrefix := ""
+
+	for _, group := range h.groups {
+		groupPrefix += group + "."
+	}
+
+	record.Attrs(func(attr slog.Attr) bool {
+		addRecordAttr(&bufferedRecord, groupPrefix, attr)
+		return true
+	})
+
+	h.buffer.add(bufferedRecord)
+
+	return nil
+}
+
+func (h *recordsBufferHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	groupPrefix := ""
+
+	for _, group := range h.groups {
+		groupPrefix += group + "."
+	}
+
+	handlerAttrs := make([]slog.Attr, 0, len(h.attrs)+len(attrs))
+	handlerAttrs = append(handlerAttrs, h.attrs...)
+
+	for _, attr := range attrs {
+		attr.Key = groupPrefix + attr.Key
+		handlerAttrs = append(handlerAttrs, attr)
+	}
+
+	return &recordsBufferHandler{
+		buffer: h.buffer,
+		level:  h.level,
+		attrs:  handlerAttrs,
+		groups: h.groups,
+	}
+}
+
+func (h *recordsBufferHandler) WithGroup(name string) slog.Handler {
+	groups := make([]string, 0, len(h.groups)+1)
+	groups = append(groups, h.groups...)
+
+	return &recordsBufferHandler{
+		buffer: h.buffer,
+		level:  h.level,
+		attrs:  h.attrs,
+		groups: append(groups, name),
+	}
+}
+
+func addRecordAttr(record *Record, groupPrefix string, attr slog.Attr) {
+	value := attr.Value.Resolve()
+	key := groupPrefix + attr.Key
+
+	if value.Kind() == slog.KindGroup {
+		for _, groupAttr := range value.Group() {
+			addRecordAttr(record, key+".", groupAttr)
+		}
+
+		return
+	}
+
+	switch key {
+	case "component":
+		record.Component = value.String()
+	case "request_id":
+		record.RequestID = value.String()
+	default:
+		record.Attrs[key] = value.String()
+	}
+}
@@ -0,0 +1,219 @@
+package logging
+
+import (
+	"context"
+	"log/slog"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+func TestRecordsBufferSnapshot(t *testing.T) {
+	testCases := []struct {
+		name             string
+		size             int
+		adds             int
+		expectedSeqs     []uint64
+		expectedMessages []string
+	}{
+		{
+			name:             "empty",
+			size:             3,
+			adds:             0,
+			expectedSeqs:     []uint64{},
+			expectedMessages: []string{},
+		},
+		{
+			name:             "not full",
+			size:             3,
+			adds:             2,
+			expectedSeqs:     []uint64{1, 2},
+			expectedMessages: []string{"0", "1"},
+		},
+		{
+			name:             "full",
+			size:             3,
+			adds:             3,
+			expectedSeqs:     []uint64{1, 2, 3},
+			expectedMessages: []string{"0", "1", "2"},
+		},
+		{
+			name:             "wrapped",
+			size:             3,
+			adds:             5,
+			expectedSeqs:     []uint64{3, 4, 5},
+			expectedMessages: []string{"2", "3", "4"},
+		},
+		{
+			name:             "wrapped twice",
+			size:             3,
+			adds:             6,
+			expectedSeqs:     []uint64{4, 5, 6},
+			expectedMessages: []string{"3", "4", "5"},
+		},
+		{
+			name:             "disabled",
+			size:             0,
+			adds:             2,
+			expectedSeqs:     []uint64{},
+			expectedMessages: []string{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			buffer := newRecordsBuffer(tc.size)
+
+			for i := 0; i < tc.adds; i++ {
+				buffer.add(Record{Message: strconv.Itoa(i)})
+			}
+
+			seqs := []uint64{}
+			messages := []string{}
+
+			for _, record := range buffer.snapshot() {
+				seqs = append(seqs, record.Seq)
+				messages = append(messages, record.Message)
+			}
+
+			if !reflect.DeepEqual(seqs, tc.expectedSeqs) {
+				t.Fatalf("expected seqs %v, got %v", tc.expectedSeqs, seqs)
+			}
+
+			if !reflect.DeepEqual(messages, tc.expectedMessages) {
+				t.Fatalf("expected messages %v, got %v", tc.expectedMessages, messages)
+			}
+		})
+	}
+}
+
+func TestRecordsBufferSubscribe(t *testing.T) {
+	buffer := newRecordsBuffer(0)
+
+	records, unsubscribe := buffer.subscribe()
+	defer unsubscribe()
+
+	buffer.add(Record{Message: "a"})
+	buffer.add(Record{Message: "b"})
+
+	for _, expectedSeq := range []uint64{1, 2} {
+		record := <-records
+
+		if record.Seq != expectedSeq {
+			t.Fatalf("expected seq %d, got %d", expectedSeq, record.Seq)
+		}
+	}
+
+	// The subscriber lags too far behind
+	for i := 0; i <= recordsSubscriberBufferSize; i++ {
+		buffer.add(Record{})
+	}
+
+	receivedRecords := 0
+
+	for range records {
+		receivedRecords++
+	}
+
+	if receivedRecords != recordsSubscriberBufferSize {
+		t.Fatalf(
+			"expected %d records before close, got %d",
+			recordsSubscriberBufferSize,
+			receivedRecords,
+		)
+	}
+
+	// Already unsubscribed
+	unsubscribe()
+}
+
+func TestRecordsFilterMatch(t *testing.T) {
+	testCases := []struct {
+		name          string
+		filter        RecordsFilter
+		record        Record
+		expectedMatch bool
+	}{
+		{
+			name:          "no filter",
+			filter:        RecordsFilter{MinLevel: slog.LevelDebug},
+			record:        Record{Level: slog.LevelDebug, Component: "network"},
+			expectedMatch: true,
+		},
+		{
+			name:          "level too low",
+			filter:        RecordsFilter{MinLevel: slog.LevelWarn},
+			record:        Record{Level: slog.LevelInfo},
+			expectedMatch: false,
+		},
+		{
+			name: "component listed",
+			filter: RecordsFilter{
+				MinLevel:   slog.LevelInfo,
+				Components: []string{"env", "network"},
+			},
+			record:        Record{Level: slog.LevelError, Component: "network"},
+			expectedMatch: true,
+		},
+		{
+			name: "component not listed",
+			filter: RecordsFilter{
+				MinLevel:   slog.LevelInfo,
+				Components: []string{"env"},
+			},
+			record:        Record{Level: slog.LevelError, Component: "network"},
+			expectedMatch: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if match := tc.filter.Match(tc.record); match != tc.expectedMatch {
+				t.Fatalf("expected match %t, got %t", tc.expectedMatch, match)
+			}
+		})
+	}
+}
+
+func TestRecordsBufferHandler(t *testing.T) {
+	buffer := newRecordsBuffer(10)
+
+	logger := slog.New(&recordsBufferHandler{
+		buffer: buffer,
+		level:  slog.LevelInfo,
+	})
+
+	logger.Debug("ignored")
+
+	logger.
+		With("component", "network", "request_id", "req", "port", 8080).
+		WithGroup("conn").
+		With("id", 1).
+		InfoContext(
+			context.Background(),
+			"accepted",
+			slog.Group("addr", "ip", "127.0.0.1"),
+		)
+
+	records := buffer.snapshot()
+
+	if len(records) != 1 {
+		t.Fatalf("expected one record, got %d", len(records))
+	}
+
+	record := records[0]
+
+	if record.Component != "network" || record.RequestID != "req" || record.Message != "accepted" {
+		t.Fatalf("unexpected record %+v", record)
+	}
+
+	expectedAttrs := map[string]string{
+		"port":         "8080",
+		"conn.id":      "1",
+		"conn.addr.ip": "127.0.0.1",
+	}
+
+	if !reflect.DeepEqual(record.Attrs, expectedAttrs) {
+		t.Fatalf("expected attrs %v, got %v", expectedAttrs, record.Attrs)
+	}
+}
@@ -0,0 +1,138 @@
+package logging
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// rotatingFile is a log file that is rotated once its
+// size exceeds "maxSize" bytes. The rotated files are
+// renamed "<path>.1" (most recent) to "<path>.<maxBackups>".
+type rotatingFile struct {
+	path       string
+	maxSize    int64
+	maxBackups int
+
+	mutex sync.Mutex
+	file  *os.File
+	size  int64
+}
+
+func newRotatingFile(
+	path string,
+	maxSize int64,
+	maxBackups int,
+) (*rotatingFile, error) {
+
+	// Make sure that the logs could be read by the agent in the host
+	err := os.MkdirAll(filepath.Dir(path), 0770)
+
+	if err != nil {
+		return nil, err
+	}
+
+	rotatingFile := &rotatingFile{
+		path:       path,
+		maxSize:    maxSize,
+		maxBackups: maxBackups,
+	}
+
+	if err := rotatingFile.open(); err != nil {
+		return nil, err
+	}
+
+	return rotatingFile, nil
+}
+
+func (r *rotatingFile) Write(p []byte) (int, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if r.maxSize > 0 && r.size > 0 && r.size+int64(len(p)) > r.maxSize {
+		if err := r.rotate(); err != nil {
+			return 0, err
+		}
+	}
+
+	n, err := r.file.Write(p)
+	r.size += int64(n)
+
+	return n, err
+}
+
+func (r *rotatingFile) Close() error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	return r.file.Close()
+}
+
+// Must be called with the mutex held
+func (r *rotatingFile) rotate() error {
+	if err := r.file.Close(); err != nil {
+		return err
+	}
+
+	if r.maxBackups <= 0 {
+		if err := os.Remove(r.path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+
+		return r.open()
+	}
+
+	for backup := r.maxBackups - 1; backup > 0; backup-- {
+		err := os.Rename(
+			r.backupPath(backup),
+			r.backupPath(backup+1),
+		)
+
+		if err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+
+	if err := os.Rename(r.path, r.backupPath(1)); err != nil {
+		return err
+	}
+
+	return r.open()
+}
+
+// Must be called with the mutex held
+func (r *rotatingFile) open() error {
+	file, err := os.OpenFile(
+		r.path,
+		os.O_CREATE|os.O_WRONLY|os.O_APPEND,
+		0660,
+	)
+
+	if err != nil {
+		return err
+	}
+
+	// Overwrite umask.
+	// See: https://stackoverflow.com/questions/50257981/ioutils-writefile-not-respecting-permissions
+	if err := file.Chmod(0660); err != nil {
+		file.Close()
+		return err
+	}
+
+	fileInfo, err := file.Stat()
+
+	if err != nil {
+		file.Close()
+		return err
+	}
+
+	r.file = file
+	r.size = fileInfo.Size()
+
+	return nil
+}
+
+func (r *rotatingFile) backupPath(backup int) string {
+	return fmt.Sprintf("%s.%d", r.path, backup)
+}
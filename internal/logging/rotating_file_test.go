@@ -0,0 +1,116 @@
+package logging
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestRotatingFileWrite(t *testing.T) {
+	testCases := []struct {
+		name            string
+		maxBackups      int
+		expectedContent map[string]string
+	}{
+		{
+			name:       "with backups",
+			maxBackups: 2,
+			expectedContent: map[string]string{
+				"agent.log":   "line 4\n",
+				"agent.log.1": "line 3\n",
+				"agent.log.2": "line 2\n",
+			},
+		},
+		{
+			name:       "without backups",
+			maxBackups: 0,
+			expectedContent: map[string]string{
+				"agent.log": "line 4\n",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			logsDirPath := t.TempDir()
+			logFilePath := filepath.Join(logsDirPath, "agent.log")
+
+			rotatingFile, err := newRotatingFile(logFilePath, 10, tc.maxBackups)
+
+			if err != nil {
+				t.Fatalf("unexpected error %v", err)
+			}
+
+			// Each line exceeds the max size once added to the previous one
+			for _, line := range []string{"line 1\n", "line 2\n", "line 3\n", "line 4\n"} {
+				if _, err := rotatingFile.Write([]byte(line)); err != nil {
+					t.Fatalf("unexpected error %v", err)
+				}
+			}
+
+			if err := rotatingFile.Close(); err != nil {
+				t.Fatalf("unexpected error %v", err)
+			}
+
+			for fileName, expectedContent := range tc.expectedContent {
+				content, err := os.ReadFile(filepath.Join(logsDirPath, fileName))
+
+				if err != nil {
+					t.Fatalf("unexpected error %v", err)
+				}
+
+				if string(content) != expectedContent {
+					t.Fatalf("expected %q in %s, got %q", expectedContent, fileName, content)
+				}
+			}
+
+			extraBackupPath := rotatingFile.backupPath(tc.maxBackups + 1)
+
+			if _, err := os.Stat(extraBackupPath); !errors.Is(err, os.ErrNotExist) {
+				t.Fatalf("expected %s to not exist", extraBackupPath)
+			}
+		})
+	}
+}
+
+func TestRotatingFileAppend(t *testing.T) {
+	logFilePath := filepath.Join(t.TempDir(), "agent.log")
+
+	if err := os.WriteFile(logFilePath, []byte("line 1\n"), 0660); err != nil {
+		t.Fatal(err)
+	}
+
+	rotatingFile, err := newRotatingFile(logFilePath, 10, 1)
+
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	// The size of the existing file is taken into account
+	if _, err := rotatingFile.Write([]byte("line 2\n")); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	rotatingFile.Close()
+
+	backupContent, err := os.ReadFile(rotatingFile.backupPath(1))
+
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	if string(backupContent) != "line 1\n" {
+		t.Fatalf("expected the existing content to be rotated, got %q", backupContent)
+	}
+
+	fileInfo, err := os.Stat(logFilePath)
+
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	if fileInfo.Mode().Perm() != 0660 {
+		t.Fatalf("expected the mode 0660, got %o", fileInfo.Mode().Perm())
+	}
+}
@@ -0,0 +1,167 @@
+package metrics
+
+import (
+	"bytes"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/common/expfmt"
+)
+
+const namespace = "yolo_agent_container"
+
+// We use our own registry instead of the default one
+// to make sure that only the metrics declared here are exposed.
+var registry = prometheus.NewRegistry()
+
+var (
+	InitDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "init",
+			Name:      "duration_seconds",
+			Help:      "Duration of the Init calls, by status.",
+			Buckets:   []float64{1, 5, 10, 30, 60, 120, 300, 600},
+		},
+		[]string{"status"},
+	)
+
+	InitPhaseDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "init",
+			Name:      "phase_duration_seconds",
+			Help:      "Duration of each Init phase.",
+			Buckets:   []float64{0.1, 0.5, 1, 5, 10, 30, 60, 120, 300},
+		},
+		[]string{"phase"},
+	)
+
+	InitPhaseFailures = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "init",
+			Name:      "phase_failures_total",
+			Help:      "Number of Init failures, by phase.",
+		},
+		[]string{"phase"},
+	)
+
+	GitAccessChecks = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "git",
+			Name:      "access_checks_total",
+			Help:      "Number of GitHub repository access checks (run before cloning), by result.",
+		},
+		[]string{"result"},
+	)
+
+	ActiveProxies = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: "network",
+			Name:      "active_proxies",
+			Help:      "Number of running localhost proxies.",
+		},
+	)
+
+	ProxyConnections = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "network",
+			Name:      "proxy_connections_total",
+			Help:      "Number of connections handled by the localhost proxies, by status.",
+		},
+		[]string{"status"},
+	)
+
+	ProxyBytesForwarded = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "network",
+			Name:      "proxy_bytes_forwarded_total",
+			Help:      "Number of bytes forwarded by the localhost proxies, by port and direction.",
+		},
+		[]string{"port", "direction"},
+	)
+
+	ProxyConnectionsRejected = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "network",
+			Name:      "proxy_connections_rejected_total",
+			Help:      "Number of connections rejected by the localhost proxies limits, by port and reason.",
+		},
+		[]string{"port", "reason"},
+	)
+
+	ReconcileDuration = prometheus.NewHistogram(
+		prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "network",
+			Name:      "reconcile_duration_seconds",
+			Help:      "Duration of the localhost proxies reconcile loop iterations.",
+			Buckets:   []float64{0.0005, 0.001, 0.0025, 0.005, 0.01, 0.025, 0.05, 0.1},
+		},
+	)
+)
+
+const (
+	InitStatusSuccess = "success"
+	InitStatusFailure = "failure"
+
+	GitAccessCheckResultOK              = "ok"
+	GitAccessCheckResultHostKeyMismatch = "host_key_mismatch"
+	GitAccessCheckResultHostKeyUnknown  = "host_key_unknown"
+	GitAccessCheckResultAuthFailed      = "auth_failed"
+	GitAccessCheckResultRepoNotFound    = "repo_not_found"
+	GitAccessCheckResultUnreachable     = "unreachable"
+	GitAccessCheckResultUnknown         = "unknown"
+
+	ProxyConnStatusAccepted = "accepted"
+	ProxyConnStatusFailed   = "failed"
+
+	// From the host to the service running in the container
+	ProxyDirectionInbound = "inbound"
+	// From the service running in the container to the host
+	ProxyDirectionOutbound = "outbound"
+)
+
+func init() {
+	registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+
+		InitDuration,
+		InitPhaseDuration,
+		InitPhaseFailures,
+		GitAccessChecks,
+		ActiveProxies,
+		ProxyConnections,
+		ProxyBytesForwarded,
+		ProxyConnectionsRejected,
+		ReconcileDuration,
+	)
+}
+
+// Gather returns the current state of
+// all the metrics in the Prometheus text format.
+func Gather() (string, error) {
+	metricFamilies, err := registry.Gather()
+
+	if err != nil {
+		return "", err
+	}
+
+	var output bytes.Buffer
+	encoder := expfmt.NewEncoder(&output, expfmt.FmtText)
+
+	for _, metricFamily := range metricFamilies {
+		if err := encoder.Encode(metricFamily); err != nil {
+			return "", err
+		}
+	}
+
+	return output.String(), nil
+}
@@ -0,0 +1,45 @@
+package metrics
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGather(t *testing.T) {
+	InitDuration.WithLabelValues(InitStatusSuccess).Observe(12)
+	ActiveProxies.Set(2)
+
+	output, err := Gather()
+
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	expectedLines := []string{
+		`yolo_agent_container_init_duration_seconds_count{status="success"} 1`,
+		`yolo_agent_container_init_duration_seconds_bucket{status="success",le="30"} 1`,
+		`yolo_agent_container_init_duration_seconds_bucket{status="success",le="10"} 0`,
+		`yolo_agent_container_network_active_proxies 2`,
+	}
+
+	for _, expectedLine := range expectedLines {
+		if !strings.Contains(output, expectedLine+"\n") {
+			t.Fatalf("expected %q in the output, got %q", expectedLine, output)
+		}
+	}
+
+	// Only the metrics of our registry are exposed
+	// (with the Go and process collectors)
+	for _, line := range strings.Split(output, "\n") {
+		if len(line) == 0 || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if !strings.HasPrefix(line, namespace+"_") &&
+			!strings.HasPrefix(line, "go_") &&
+			!strings.HasPrefix(line, "process_") {
+
+			t.Fatalf("unexpected metric %q", line)
+		}
+	}
+}
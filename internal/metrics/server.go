@@ -0,0 +1,18 @@
+package metrics
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+func ListenAndServe(serverAddr string) error {
+	mux := http.NewServeMux()
+
+	mux.Handle(
+		"/metrics",
+		promhttp.HandlerFor(registry, promhttp.HandlerOpts{}),
+	)
+
+	return http.ListenAndServe(serverAddr, mux)
+}
@@ -0,0 +1,91 @@
+package network
+
+import (
+	"errors"
+	"net"
+)
+
+var ErrNoContainerBindAddr = errors.New("no container address to bind the proxies on")
+
+// resolveBindAddrs returns the configured bind addresses
+// or, when not configured, the global unicast addresses
+// (IPv4 and IPv6) assigned to the container interfaces.
+func (p *ProxyManager) resolveBindAddrs() ([]string, error) {
+	if len(p.config.BindAddrs) > 0 {
+		return p.config.BindAddrs, nil
+	}
+
+	containerIPs, err := getContainerIPs()
+
+	if err != nil {
+		return nil, err
+	}
+
+	bindAddrs := []string{}
+
+	for _, containerIP := range containerIPs {
+		// Loopback and link-local addresses are not
+		// reachable from the host (nor usable without zone)
+		if !containerIP.IsGlobalUnicast() {
+			continue
+		}
+
+		bindAddrs = append(bindAddrs, containerIP.String())
+	}
+
+	if len(bindAddrs) == 0 {
+		return nil, ErrNoContainerBindAddr
+	}
+
+	return bindAddrs, nil
+}
+
+// proxyBindAddrs returns, among the resolved bind
+// addresses, the ones the proxy for "listener" needs to
+// listen on. Listeners are exposed on the addresses of
+// the same family, when the container has any.
+// The wildcard listeners are only exposed on the
+// addresses they are not already reachable on.
+func proxyBindAddrs(
+	listener localhostListener,
+	bindAddrs []string,
+) []string {
+
+	if listener.wildcard {
+		// "::" listeners accept IPv4 connections too
+		if !isIPv4Addr(listener.listeningAddr) {
+			return []string{}
+		}
+
+		return filterAddrsByFamily(bindAddrs, false)
+	}
+
+	sameFamilyBindAddrs := filterAddrsByFamily(
+		bindAddrs,
+		isIPv4Addr(listener.listeningAddr),
+	)
+
+	if len(sameFamilyBindAddrs) > 0 {
+		return sameFamilyBindAddrs
+	}
+
+	return bindAddrs
+}
+
+func filterAddrsByFamily(addrs []string, ipv4 bool) []string {
+	filteredAddrs := []string{}
+
+	for _, addr := range addrs {
+		if isIPv4Addr(addr) == ipv4 {
+			filteredAddrs = append(filteredAddrs, addr)
+		}
+	}
+
+	return filteredAddrs
+}
+
+func isIPv4Addr(addr string) bool {
+	ip := net.ParseIP(addr)
+
+	return ip != nil && ip.To4() != nil
+}
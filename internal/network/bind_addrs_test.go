@@ -0,0 +1,107 @@
+package network
+
+import (
+	"errors"
+	"net"
+	"reflect"
+	"testing"
+)
+
+func TestProxyBindAddrs(t *testing.T) {
+	dualStackBindAddrs := []string{"172.20.0.2", "fd00::2", "10.0.0.2"}
+
+	testCases := []struct {
+		name              string
+		listener          localhostListener
+		bindAddrs         []string
+		expectedBindAddrs []string
+	}{
+		{
+			name:              "IPv4 listener",
+			listener:          localhostListener{listeningAddr: "127.0.0.1"},
+			bindAddrs:         dualStackBindAddrs,
+			expectedBindAddrs: []string{"172.20.0.2", "10.0.0.2"},
+		},
+		{
+			name:              "IPv6 listener",
+			listener:          localhostListener{listeningAddr: "::1"},
+			bindAddrs:         dualStackBindAddrs,
+			expectedBindAddrs: []string{"fd00::2"},
+		},
+		{
+			name:              "IPv6 listener on IPv4 only container",
+			listener:          localhostListener{listeningAddr: "::1"},
+			bindAddrs:         []string{"172.20.0.2"},
+			expectedBindAddrs: []string{"172.20.0.2"},
+		},
+		{
+			name:              "IPv4 listener on IPv6 only container",
+			listener:          localhostListener{listeningAddr: "127.0.0.1"},
+			bindAddrs:         []string{"fd00::2"},
+			expectedBindAddrs: []string{"fd00::2"},
+		},
+		{
+			name:              "IPv4 wildcard listener",
+			listener:          localhostListener{listeningAddr: "0.0.0.0", wildcard: true},
+			bindAddrs:         dualStackBindAddrs,
+			expectedBindAddrs: []string{"fd00::2"},
+		},
+		{
+			name:              "IPv4 wildcard listener on IPv4 only container",
+			listener:          localhostListener{listeningAddr: "0.0.0.0", wildcard: true},
+			bindAddrs:         []string{"172.20.0.2"},
+			expectedBindAddrs: []string{},
+		},
+		{
+			name:              "IPv6 wildcard listener",
+			listener:          localhostListener{listeningAddr: "::", wildcard: true},
+			bindAddrs:         dualStackBindAddrs,
+			expectedBindAddrs: []string{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			bindAddrs := proxyBindAddrs(tc.listener, tc.bindAddrs)
+
+			if !reflect.DeepEqual(bindAddrs, tc.expectedBindAddrs) {
+				t.Fatalf("expected %v, got %v", tc.expectedBindAddrs, bindAddrs)
+			}
+		})
+	}
+}
+
+func TestResolveBindAddrs(t *testing.T) {
+	config := NewDefaultProxyManagerConfig()
+	config.BindAddrs = []string{"172.20.0.2", "fd00::2"}
+
+	bindAddrs, err := NewProxyManager(config).resolveBindAddrs()
+
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	if !reflect.DeepEqual(bindAddrs, config.BindAddrs) {
+		t.Fatalf("expected the configured addresses %v, got %v", config.BindAddrs, bindAddrs)
+	}
+
+	// Discovered from the container interfaces
+	bindAddrs, err = NewProxyManager(NewDefaultProxyManagerConfig()).resolveBindAddrs()
+
+	// No network interface besides the loopback one
+	if errors.Is(err, ErrNoContainerBindAddr) {
+		return
+	}
+
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	for _, bindAddr := range bindAddrs {
+		ip := net.ParseIP(bindAddr)
+
+		if ip == nil || !ip.IsGlobalUnicast() {
+			t.Fatalf("unexpected bind address %q", bindAddr)
+		}
+	}
+}
@@ -0,0 +1,185 @@
+package network
+
+import (
+	"errors"
+	"io"
+	"net"
+	"os"
+	"sync/atomic"
+	"time"
+)
+
+// forwardConnHalf copies "src" to "dst" until EOF
+// then closes the write half of "dstConn" so that
+// the peer sees the EOF while still being able to respond.
+func forwardConnHalf(
+	dst io.Writer,
+	dstConn net.Conn,
+	src net.Conn,
+	idleTracker *connIdleTracker,
+) error {
+
+	_, err := io.Copy(
+		newIdleTimeoutWriter(dst, dstConn, idleTracker),
+		newIdleTimeoutReader(src, idleTracker),
+	)
+
+	if err != nil {
+		return err
+	}
+
+	return closeConnWrite(dstConn)
+}
+
+type closeWriter interface {
+	CloseWrite() error
+}
+
+// closeConnWrite shuts down the writing side of
+// the connection when supported (eg: *net.TCPConn).
+// Otherwise, the connection is left untouched and
+// will be closed once both directions are done.
+func closeConnWrite(conn net.Conn) error {
+	connCloseWriter, ok := conn.(closeWriter)
+
+	if !ok {
+		return nil
+	}
+
+	err := connCloseWriter.CloseWrite()
+
+	// The peer may have closed the connection entirely
+	if err != nil && errors.Is(err, net.ErrClosed) {
+		return nil
+	}
+
+	return err
+}
+
+func isConnTimeoutErr(err error) bool {
+	return errors.Is(err, os.ErrDeadlineExceeded)
+}
+
+// connIdleTracker records the last activity
+// of a forwarded connection in both directions so that
+// a connection that only transfers data in one direction
+// is not considered idle.
+type connIdleTracker struct {
+	timeout          time.Duration
+	lastActivityNano int64
+}
+
+func newConnIdleTracker(timeout time.Duration) *connIdleTracker {
+	return &connIdleTracker{
+		timeout:          timeout,
+		lastActivityNano: time.Now().UnixNano(),
+	}
+}
+
+func (c *connIdleTracker) touch() {
+	atomic.StoreInt64(&c.lastActivityNano, time.Now().UnixNano())
+}
+
+func (c *connIdleTracker) lastActivity() time.Time {
+	return time.Unix(0, atomic.LoadInt64(&c.lastActivityNano))
+}
+
+type idleTimeoutReader struct {
+	conn        net.Conn
+	idleTracker *connIdleTracker
+}
+
+func newIdleTimeoutReader(
+	conn net.Conn,
+	idleTracker *connIdleTracker,
+) *idleTimeoutReader {
+
+	return &idleTimeoutReader{
+		conn:        conn,
+		idleTracker: idleTracker,
+	}
+}
+
+func (i *idleTimeoutReader) Read(p []byte) (int, error) {
+	if i.idleTracker.timeout <= 0 {
+		return i.conn.Read(p)
+	}
+
+	for {
+		err := i.conn.SetReadDeadline(
+			i.idleTracker.lastActivity().Add(i.idleTracker.timeout),
+		)
+
+		if err != nil {
+			return 0, err
+		}
+
+		n, err := i.conn.Read(p)
+
+		if n > 0 {
+			i.idleTracker.touch()
+		}
+
+		// The other direction may have been active
+		// in the meantime. Wait again in this case.
+		if n == 0 && isConnTimeoutErr(err) &&
+			time.Since(i.idleTracker.lastActivity()) < i.idleTracker.timeout {
+
+			continue
+		}
+
+		return n, err
+	}
+}
+
+// idleTimeoutWriter fails the writes that make no progress
+// during the idle timeout (eg: the peer doesn't read anymore)
+// given that a blocked write is not seen by the readers.
+type idleTimeoutWriter struct {
+	writer      io.Writer
+	conn        net.Conn
+	idleTracker *connIdleTracker
+}
+
+func newIdleTimeoutWriter(
+	writer io.Writer,
+	conn net.Conn,
+	idleTracker *connIdleTracker,
+) *idleTimeoutWriter {
+
+	return &idleTimeoutWriter{
+		writer:      writer,
+		conn:        conn,
+		idleTracker: idleTracker,
+	}
+}
+
+func (i *idleTimeoutWriter) Write(p []byte) (int, error) {
+	if i.idleTracker.timeout <= 0 {
+		return i.writer.Write(p)
+	}
+
+	written := 0
+
+	for {
+		err := i.conn.SetWriteDeadline(time.Now().Add(i.idleTracker.timeout))
+
+		if err != nil {
+			return written, err
+		}
+
+		n, err := i.writer.Write(p[written:])
+		written += n
+
+		if n > 0 {
+			i.idleTracker.touch()
+		}
+
+		// The peer is slow but still reading
+		if n > 0 && isConnTimeoutErr(err) {
+			continue
+		}
+
+		return written, err
+	}
+}
@@ -0,0 +1,212 @@
+package network
+
+import (
+	"sync"
+	"sync/atomic"
+	"syscall"
+
+	"golang.org/x/time/rate"
+)
+
+// Each forwarded connection holds two file descriptors:
+// the accepted connection and the one to the target.
+const fdsPerProxyConn = 2
+
+// The file descriptors left to the rest of the agent
+// (gRPC server, init scripts...) when the total budget
+// is derived from the "RLIMIT_NOFILE" soft limit.
+const reservedFDs = 256
+
+// ConnLimitsConfig bounds the resources used by the
+// connections forwarded by the localhost proxies.
+// A zero value disables the corresponding limit.
+type ConnLimitsConfig struct {
+	// Concurrent connections per forwarded port
+	MaxConnsPerPort int
+	// File descriptors held by the connections of each forwarded port
+	MaxFDsPerPort int
+	// File descriptors held by the connections of all the forwarded
+	// ports. Zero derives it from the "RLIMIT_NOFILE" soft limit
+	// (minus a reserve for the rest of the agent). A negative value
+	// disables the limit.
+	MaxFDsTotal int
+	// Accepted connections per second, per forwarded port
+	AcceptRatePerPort float64
+	// Connections accepted in a burst above "AcceptRatePerPort".
+	// Defaults to 1 when the rate limit is enabled.
+	AcceptBurstPerPort int
+}
+
+type ProxyConnRejectReason string
+
+const (
+	ProxyConnRejectReasonMaxConns    ProxyConnRejectReason = "max_conns"
+	ProxyConnRejectReasonMaxFDs      ProxyConnRejectReason = "max_fds"
+	ProxyConnRejectReasonMaxFDsTotal ProxyConnRejectReason = "max_fds_total"
+	ProxyConnRejectReasonRateLimited ProxyConnRejectReason = "rate_limited"
+)
+
+// fdsBudget tracks the file descriptors
+// held by the connections of all the proxies.
+type fdsBudget struct {
+	max  int64
+	used int64
+}
+
+func newFDsBudget(maxFDs int) *fdsBudget {
+	if maxFDs == 0 {
+		maxFDs = computeDefaultMaxFDsTotal()
+	}
+
+	return &fdsBudget{
+		max: int64(maxFDs),
+	}
+}
+
+func computeDefaultMaxFDsTotal() int {
+	var rlimit syscall.Rlimit
+
+	err := syscall.Getrlimit(syscall.RLIMIT_NOFILE, &rlimit)
+
+	// Disabled if unknown
+	if err != nil || rlimit.Cur <= reservedFDs || rlimit.Cur == ^uint64(0) {
+		return -1
+	}
+
+	return int(rlimit.Cur - reservedFDs)
+}
+
+func (f *fdsBudget) acquire(n int64) bool {
+	if f.max < 0 {
+		return true
+	}
+
+	if atomic.AddInt64(&f.used, n) > f.max {
+		atomic.AddInt64(&f.used, -n)
+		return false
+	}
+
+	return true
+}
+
+func (f *fdsBudget) release(n int64) {
+	if f.max < 0 {
+		return
+	}
+
+	atomic.AddInt64(&f.used, -n)
+}
+
+// proxyConnLimiter enforces the limits of a single
+// forwarded port before each accepted connection is forwarded.
+type proxyConnLimiter struct {
+	listeningPort uint64
+	config        ConnLimitsConfig
+	totalFDs      *fdsBudget
+	rateLimiter   *rate.Limiter
+
+	mutex       sync.Mutex
+	activeConns int
+	// Used to log only the first
+	// rejection of a series
+	rejecting bool
+}
+
+func newProxyConnLimiter(
+	listeningPort uint64,
+	config ConnLimitsConfig,
+	totalFDs *fdsBudget,
+) *proxyConnLimiter {
+
+	limiter := &proxyConnLimiter{
+		listeningPort: listeningPort,
+		config:        config,
+		totalFDs:      totalFDs,
+	}
+
+	if config.AcceptRatePerPort > 0 {
+		burst := config.AcceptBurstPerPort
+
+		if burst <= 0 {
+			burst = 1
+		}
+
+		limiter.rateLimiter = rate.NewLimiter(
+			rate.Limit(config.AcceptRatePerPort),
+			burst,
+		)
+	}
+
+	return limiter
+}
+
+// acquire returns a function that must be called once the
+// connection is closed or, when the connection must be rejected,
+// the reason of the rejection.
+func (l *proxyConnLimiter) acquire() (func(), ProxyConnRejectReason) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	rejectReason := l.checkLimits()
+
+	if len(rejectReason) > 0 {
+		if !l.rejecting {
+			logger.Warn(
+				"proxy connections rejected",
+				"port", l.listeningPort,
+				"reason", rejectReason,
+				"active_conns", l.activeConns,
+			)
+		}
+
+		l.rejecting = true
+
+		return nil, rejectReason
+	}
+
+	l.rejecting = false
+	l.activeConns++
+
+	var once sync.Once
+
+	release := func() {
+		once.Do(func() {
+			l.mutex.Lock()
+			defer l.mutex.Unlock()
+
+			l.activeConns--
+			l.totalFDs.release(fdsPerProxyConn)
+		})
+	}
+
+	return release, ""
+}
+
+// Must be called with the mutex held
+func (l *proxyConnLimiter) checkLimits() ProxyConnRejectReason {
+	if l.config.MaxConnsPerPort > 0 &&
+		l.activeConns >= l.config.MaxConnsPerPort {
+
+		return ProxyConnRejectReasonMaxConns
+	}
+
+	if l.config.MaxFDsPerPort > 0 &&
+		(l.activeConns+1)*fdsPerProxyConn > l.config.MaxFDsPerPort {
+
+		return ProxyConnRejectReasonMaxFDs
+	}
+
+	if !l.totalFDs.acquire(fdsPerProxyConn) {
+		return ProxyConnRejectReasonMaxFDsTotal
+	}
+
+	// Checked last given that a token is consumed
+	// (the connections rejected by the other
+	// limits must not use the rate budget)
+	if l.rateLimiter != nil && !l.rateLimiter.Allow() {
+		l.totalFDs.release(fdsPerProxyConn)
+		return ProxyConnRejectReasonRateLimited
+	}
+
+	return ""
+}
@@ -0,0 +1,179 @@
+package network
+
+import (
+	"testing"
+)
+
+func TestProxyConnLimiterAcquire(t *testing.T) {
+	testCases := []struct {
+		name          string
+		config        ConnLimitsConfig
+		maxFDsTotal   int
+		conns         int
+		expectedLast  ProxyConnRejectReason
+		expectedConns int
+	}{
+		{
+			name:          "no limits",
+			config:        ConnLimitsConfig{},
+			maxFDsTotal:   -1,
+			conns:         100,
+			expectedConns: 100,
+		},
+		{
+			name:          "max conns per port",
+			config:        ConnLimitsConfig{MaxConnsPerPort: 3},
+			maxFDsTotal:   -1,
+			conns:         4,
+			expectedLast:  ProxyConnRejectReasonMaxConns,
+			expectedConns: 3,
+		},
+		{
+			name:          "max fds per port",
+			config:        ConnLimitsConfig{MaxFDsPerPort: 5},
+			maxFDsTotal:   -1,
+			conns:         3,
+			expectedLast:  ProxyConnRejectReasonMaxFDs,
+			expectedConns: 2,
+		},
+		{
+			name:          "max fds total",
+			config:        ConnLimitsConfig{},
+			maxFDsTotal:   4,
+			conns:         3,
+			expectedLast:  ProxyConnRejectReasonMaxFDsTotal,
+			expectedConns: 2,
+		},
+		{
+			name: "accept rate",
+			config: ConnLimitsConfig{
+				AcceptRatePerPort:  0.001,
+				AcceptBurstPerPort: 2,
+			},
+			maxFDsTotal:   -1,
+			conns:         3,
+			expectedLast:  ProxyConnRejectReasonRateLimited,
+			expectedConns: 2,
+		},
+		{
+			name:          "accept rate with default burst",
+			config:        ConnLimitsConfig{AcceptRatePerPort: 0.001},
+			maxFDsTotal:   -1,
+			conns:         2,
+			expectedLast:  ProxyConnRejectReasonRateLimited,
+			expectedConns: 1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			limiter := newProxyConnLimiter(
+				8080,
+				tc.config,
+				newFDsBudget(tc.maxFDsTotal),
+			)
+
+			acceptedConns := 0
+			var lastRejectReason ProxyConnRejectReason
+
+			for i := 0; i < tc.conns; i++ {
+				release, rejectReason := limiter.acquire()
+
+				if len(rejectReason) > 0 {
+					lastRejectReason = rejectReason
+					continue
+				}
+
+				if release == nil {
+					t.Fatal("expected a release function")
+				}
+
+				acceptedConns++
+			}
+
+			if acceptedConns != tc.expectedConns {
+				t.Fatalf("expected %d accepted conns, got %d", tc.expectedConns, acceptedConns)
+			}
+
+			if lastRejectReason != tc.expectedLast {
+				t.Fatalf("expected reject reason %q, got %q", tc.expectedLast, lastRejectReason)
+			}
+		})
+	}
+}
+
+func TestProxyConnLimiterRelease(t *testing.T) {
+	totalFDs := newFDsBudget(fdsPerProxyConn)
+
+	limiter := newProxyConnLimiter(
+		8080,
+		ConnLimitsConfig{MaxConnsPerPort: 1},
+		totalFDs,
+	)
+
+	release, rejectReason := limiter.acquire()
+
+	if len(rejectReason) > 0 {
+		t.Fatalf("unexpected rejection %q", rejectReason)
+	}
+
+	if _, rejectReason := limiter.acquire(); rejectReason != ProxyConnRejectReasonMaxConns {
+		t.Fatalf("expected %q, got %q", ProxyConnRejectReasonMaxConns, rejectReason)
+	}
+
+	// Released only once
+	release()
+	release()
+
+	if totalFDs.used != 0 {
+		t.Fatalf("expected no used fds, got %d", totalFDs.used)
+	}
+
+	if _, rejectReason := limiter.acquire(); len(rejectReason) > 0 {
+		t.Fatalf("unexpected rejection %q after release", rejectReason)
+	}
+}
+
+func TestProxyConnLimiterRateLimitCheckedLast(t *testing.T) {
+	totalFDs := newFDsBudget(fdsPerProxyConn)
+
+	limiter := newProxyConnLimiter(
+		8080,
+		ConnLimitsConfig{
+			AcceptRatePerPort:  0.001,
+			AcceptBurstPerPort: 2,
+		},
+		totalFDs,
+	)
+
+	release, rejectReason := limiter.acquire()
+
+	if len(rejectReason) > 0 {
+		t.Fatalf("unexpected rejection %q", rejectReason)
+	}
+
+	// Must not consume the second token
+	if _, rejectReason := limiter.acquire(); rejectReason != ProxyConnRejectReasonMaxFDsTotal {
+		t.Fatalf("expected %q, got %q", ProxyConnRejectReasonMaxFDsTotal, rejectReason)
+	}
+
+	release()
+
+	release, rejectReason = limiter.acquire()
+
+	if len(rejectReason) > 0 {
+		t.Fatalf("unexpected rejection %q after release", rejectReason)
+	}
+
+	release()
+
+	// The burst is now consumed
+	if _, rejectReason := limiter.acquire(); rejectReason != ProxyConnRejectReasonRateLimited {
+		t.Fatalf("expected %q, got %q", ProxyConnRejectReasonRateLimited, rejectReason)
+	}
+
+	// Released when rate limited
+	if totalFDs.used != 0 {
+		t.Fatalf("expected no used fds, got %d", totalFDs.used)
+	}
+}
@@ -0,0 +1,347 @@
+package network
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Requests could also be routed using
+// a path prefix (eg: "/port/3000/index.html")
+const httpProxyPathPrefix = "/port/"
+
+type httpProxyRouteContextKey struct{}
+
+// httpProxyRoute is passed to the reverse proxy of the
+// requested port via the context of the request given
+// that the target could change between requests.
+type httpProxyRoute struct {
+	targetAddr string
+	// Empty for the host-based routes
+	pathPrefix string
+}
+
+type HTTPProxyConfig struct {
+	// When set, only the "<port>.<WorkspaceName>.localhost"
+	// hosts are routed. Otherwise, "<port>.<anything>.localhost"
+	// and "<port>.localhost" are.
+	WorkspaceName string
+}
+
+// HTTPProxy is a single HTTP entrypoint that routes the requests
+// to the loopback listeners found by the proxy manager,
+// using the requested hostname (eg: "3000.workspace.localhost")
+// or a path prefix (eg: "/port/3000/"). It gives stable
+// preview URLs per port. WebSocket upgrades are supported.
+type HTTPProxy struct {
+	config       HTTPProxyConfig
+	proxyManager *ProxyManager
+	transport    *http.Transport
+
+	reverseProxiesMutex sync.Mutex
+	// One per requested port, created on first use
+	reverseProxies map[uint64]*httputil.ReverseProxy
+}
+
+func NewHTTPProxy(
+	config HTTPProxyConfig,
+	proxyManager *ProxyManager,
+) *HTTPProxy {
+
+	return &HTTPProxy{
+		config:       config,
+		proxyManager: proxyManager,
+		transport: &http.Transport{
+			Proxy: nil, // The targets are always local
+			DialContext: (&net.Dialer{
+				Timeout:   10 * time.Second,
+				KeepAlive: 30 * time.Second,
+			}).DialContext,
+			MaxIdleConnsPerHost: 16,
+			IdleConnTimeout:     90 * time.Second,
+		},
+		reverseProxies: map[uint64]*httputil.ReverseProxy{},
+	}
+}
+
+func (h *HTTPProxy) ListenAndServe(addr string) error {
+	server := &http.Server{
+		Addr:              addr,
+		Handler:           h,
+		ReadHeaderTimeout: 30 * time.Second,
+	}
+
+	return server.ListenAndServe()
+}
+
+// ListenAndServeTLS is like ListenAndServe but terminates
+// TLS using the leaf certificates issued by "workspaceCA".
+func (h *HTTPProxy) ListenAndServeTLS(
+	addr string,
+	workspaceCA *WorkspaceCA,
+) error {
+
+	if workspaceCA == nil {
+		return ErrTLSTerminationDisabled
+	}
+
+	server := &http.Server{
+		Addr:              addr,
+		Handler:           h,
+		TLSConfig:         workspaceCA.TLSConfig(),
+		ReadHeaderTimeout: 30 * time.Second,
+	}
+
+	// The certificates are provided by "TLSConfig.GetCertificate"
+	return server.ListenAndServeTLS("", "")
+}
+
+func (h *HTTPProxy) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	port, pathPrefix, ok := h.parseRoute(r)
+
+	if !ok {
+		http.Error(
+			w,
+			fmt.Sprintf(
+				"Unknown route. Use \"<port>.<workspace>.localhost\" or \"%s<port>/\".",
+				httpProxyPathPrefix,
+			),
+			http.StatusNotFound,
+		)
+
+		return
+	}
+
+	targetAddr, ok := h.lookupTargetAddr(port)
+
+	if !ok {
+		http.Error(
+			w,
+			fmt.Sprintf("No service listening on port %d.", port),
+			http.StatusBadGateway,
+		)
+
+		return
+	}
+
+	routeCtx := context.WithValue(
+		r.Context(),
+		httpProxyRouteContextKey{},
+		httpProxyRoute{
+			targetAddr: targetAddr,
+			pathPrefix: pathPrefix,
+		},
+	)
+
+	h.getOrCreateReverseProxy(port).ServeHTTP(w, r.WithContext(routeCtx))
+}
+
+func (h *HTTPProxy) getOrCreateReverseProxy(port uint64) *httputil.ReverseProxy {
+	h.reverseProxiesMutex.Lock()
+	defer h.reverseProxiesMutex.Unlock()
+
+	if reverseProxy, ok := h.reverseProxies[port]; ok {
+		return reverseProxy
+	}
+
+	reverseProxy := &httputil.ReverseProxy{
+		Director:  h.buildDirector(port),
+		Transport: h.transport,
+		ErrorHandler: func(w http.ResponseWriter, r *http.Request, err error) {
+			logger.Warn(
+				"error when proxying HTTP request",
+				"port", port,
+				"error", err,
+			)
+
+			w.WriteHeader(http.StatusBadGateway)
+		},
+	}
+
+	h.reverseProxies[port] = reverseProxy
+
+	return reverseProxy
+}
+
+// parseRoute returns the requested port and,
+// for path-based routes, the path prefix to strip.
+func (h *HTTPProxy) parseRoute(r *http.Request) (uint64, string, bool) {
+	if port, ok := h.parsePortFromHost(r.Host); ok {
+		return port, "", true
+	}
+
+	if !strings.HasPrefix(r.URL.Path, httpProxyPathPrefix) {
+		return 0, "", false
+	}
+
+	pathParts := strings.SplitN(
+		strings.TrimPrefix(r.URL.Path, httpProxyPathPrefix),
+		"/",
+		2,
+	)
+
+	port, err := parsePort(pathParts[0])
+
+	if err != nil {
+		return 0, "", false
+	}
+
+	return port, httpProxyPathPrefix + pathParts[0], true
+}
+
+func (h *HTTPProxy) parsePortFromHost(host string) (uint64, bool) {
+	hostname := host
+
+	if splitHostname, _, err := net.SplitHostPort(host); err == nil {
+		hostname = splitHostname
+	}
+
+	hostnameLabels := strings.Split(strings.ToLower(hostname), ".")
+
+	if len(hostnameLabels) < 2 ||
+		hostnameLabels[len(hostnameLabels)-1] != "localhost" {
+
+		return 0, false
+	}
+
+	if len(h.config.WorkspaceName) > 0 &&
+		(len(hostnameLabels) != 3 ||
+			hostnameLabels[1] != strings.ToLower(h.config.WorkspaceName)) {
+
+		return 0, false
+	}
+
+	port, err := parsePort(hostnameLabels[0])
+
+	if err != nil {
+		return 0, false
+	}
+
+	return port, true
+}
+
+func parsePort(portAsString string) (uint64, error) {
+	port, err := strconv.ParseUint(portAsString, 10, 16)
+
+	if err != nil {
+		return 0, err
+	}
+
+	if port == 0 {
+		return 0, fmt.Errorf("invalid port 0")
+	}
+
+	return port, nil
+}
+
+// lookupTargetAddr returns the address of the loopback
+// (or wildcard) listener on "port", preferring IPv4.
+func (h *HTTPProxy) lookupTargetAddr(port uint64) (string, bool) {
+	targetAddrs := []string{}
+
+	for _, proxy := range h.proxyManager.List() {
+		if proxy.Source != ProxySourceAuto ||
+			proxy.ListeningPort != port {
+
+			continue
+		}
+
+		targetAddrs = append(targetAddrs, proxy.TargetAddr)
+	}
+
+	// Not proxied but reachable from the container
+	for _, listener := range h.proxyManager.Listeners() {
+		if listener.State != ListenerStateDirect ||
+			listener.Port != port {
+
+			continue
+		}
+
+		targetAddrs = append(targetAddrs, localhostListener{
+			listeningAddr: listener.Addr,
+			listeningPort: listener.Port,
+			wildcard:      listener.Wildcard,
+		}.dialAddr())
+	}
+
+	if len(targetAddrs) == 0 {
+		return "", false
+	}
+
+	// "127.0.0.1:port" sorts before "[::1]:port"
+	sort.Strings(targetAddrs)
+
+	return targetAddrs[0], true
+}
+
+// buildDirector returns the director of the reverse proxy
+// of "port". The target and the path prefix to strip are
+// read from the route stored in the context of the request.
+func (h *HTTPProxy) buildDirector(port uint64) func(*http.Request) {
+	return func(r *http.Request) {
+		route, _ := r.Context().Value(httpProxyRouteContextKey{}).(httpProxyRoute)
+
+		forwardedProto := "http"
+
+		if r.TLS != nil {
+			forwardedProto = "https"
+		}
+
+		r.Header.Set("X-Forwarded-Host", r.Host)
+		r.Header.Set("X-Forwarded-Proto", forwardedProto)
+
+		if len(route.pathPrefix) > 0 {
+			r.Header.Set("X-Forwarded-Prefix", route.pathPrefix)
+			stripURLPathPrefix(r.URL, route.pathPrefix)
+		}
+
+		r.URL.Scheme = "http"
+		r.URL.Host = route.targetAddr
+
+		// Dev servers usually only accept "localhost" hosts
+		r.Host = net.JoinHostPort(
+			"localhost",
+			strconv.FormatUint(port, 10),
+		)
+
+		// Prevent the default Go user-agent from being set
+		if _, ok := r.Header["User-Agent"]; !ok {
+			r.Header.Set("User-Agent", "")
+		}
+	}
+}
+
+// stripURLPathPrefix removes "pathPrefix" from both the
+// decoded and the encoded paths of "u" so that the encoded
+// characters (eg: "%2F") are forwarded as-is.
+func stripURLPathPrefix(u *url.URL, pathPrefix string) {
+	stripPrefix := func(path string) string {
+		return "/" + strings.TrimPrefix(
+			strings.TrimPrefix(path, pathPrefix),
+			"/",
+		)
+	}
+
+	u.Path = stripPrefix(u.Path)
+
+	if len(u.RawPath) == 0 {
+		return
+	}
+
+	// The prefix could have been encoded
+	// differently (eg: "/port/%33000")
+	if !strings.HasPrefix(u.RawPath, pathPrefix) {
+		u.RawPath = ""
+		return
+	}
+
+	u.RawPath = stripPrefix(u.RawPath)
+}
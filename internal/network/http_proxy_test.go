@@ -0,0 +1,289 @@
+package network
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHTTPProxyParseRoute(t *testing.T) {
+	testCases := []struct {
+		name               string
+		workspaceName      string
+		url                string
+		host               string
+		expectedPort       uint64
+		expectedPathPrefix string
+		expectedOK         bool
+	}{
+		{
+			name:         "port and workspace",
+			url:          "/index.html",
+			host:         "3000.workspace.localhost",
+			expectedPort: 3000,
+			expectedOK:   true,
+		},
+		{
+			name:         "port only",
+			url:          "/",
+			host:         "3000.localhost:8080",
+			expectedPort: 3000,
+			expectedOK:   true,
+		},
+		{
+			name:         "host case",
+			url:          "/",
+			host:         "3000.Workspace.LOCALHOST",
+			expectedPort: 3000,
+			expectedOK:   true,
+		},
+		{
+			name:          "configured workspace",
+			workspaceName: "workspace",
+			url:           "/",
+			host:          "3000.workspace.localhost",
+			expectedPort:  3000,
+			expectedOK:    true,
+		},
+		{
+			name:          "other workspace",
+			workspaceName: "workspace",
+			url:           "/",
+			host:          "3000.other.localhost",
+			expectedOK:    false,
+		},
+		{
+			name:          "port only with configured workspace",
+			workspaceName: "workspace",
+			url:           "/",
+			host:          "3000.localhost",
+			expectedOK:    false,
+		},
+		{
+			name:       "not localhost",
+			url:        "/",
+			host:       "3000.example.com",
+			expectedOK: false,
+		},
+		{
+			name:       "invalid port",
+			url:        "/",
+			host:       "70000.localhost",
+			expectedOK: false,
+		},
+		{
+			name:               "path prefix",
+			url:                "/port/3000/index.html",
+			host:               "172.20.0.2:8080",
+			expectedPort:       3000,
+			expectedPathPrefix: "/port/3000",
+			expectedOK:         true,
+		},
+		{
+			name:               "path prefix without trailing slash",
+			url:                "/port/3000",
+			host:               "172.20.0.2:8080",
+			expectedPort:       3000,
+			expectedPathPrefix: "/port/3000",
+			expectedOK:         true,
+		},
+		{
+			name:       "path prefix with port zero",
+			url:        "/port/0/",
+			host:       "172.20.0.2:8080",
+			expectedOK: false,
+		},
+		{
+			name:       "unknown route",
+			url:        "/index.html",
+			host:       "172.20.0.2:8080",
+			expectedOK: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			httpProxy := NewHTTPProxy(
+				HTTPProxyConfig{WorkspaceName: tc.workspaceName},
+				nil,
+			)
+
+			req := httptest.NewRequest("GET", tc.url, nil)
+			req.Host = tc.host
+
+			port, pathPrefix, ok := httpProxy.parseRoute(req)
+
+			if ok != tc.expectedOK {
+				t.Fatalf("expected ok %t, got %t", tc.expectedOK, ok)
+			}
+
+			if port != tc.expectedPort || pathPrefix != tc.expectedPathPrefix {
+				t.Fatalf(
+					"expected %d and %q, got %d and %q",
+					tc.expectedPort,
+					tc.expectedPathPrefix,
+					port,
+					pathPrefix,
+				)
+			}
+		})
+	}
+}
+
+func TestHTTPProxyBuildDirector(t *testing.T) {
+	testCases := []struct {
+		name                    string
+		url                     string
+		pathPrefix              string
+		expectedPath            string
+		expectedEscapedPath     string
+		expectedRawQuery        string
+		expectedForwardedPrefix string
+	}{
+		{
+			name:             "host route",
+			url:              "/index.html?page=1",
+			expectedPath:     "/index.html",
+			expectedRawQuery: "page=1",
+		},
+		{
+			name:                    "path route",
+			url:                     "/port/3000/index.html?page=1",
+			pathPrefix:              "/port/3000",
+			expectedPath:            "/index.html",
+			expectedRawQuery:        "page=1",
+			expectedForwardedPrefix: "/port/3000",
+		},
+		{
+			name:                    "path route without trailing slash",
+			url:                     "/port/3000",
+			pathPrefix:              "/port/3000",
+			expectedPath:            "/",
+			expectedForwardedPrefix: "/port/3000",
+		},
+		{
+			name:                    "path route with encoded slash",
+			url:                     "/port/3000/files/a%2Fb",
+			pathPrefix:              "/port/3000",
+			expectedPath:            "/files/a/b",
+			expectedEscapedPath:     "/files/a%2Fb",
+			expectedForwardedPrefix: "/port/3000",
+		},
+		{
+			name:                    "path route with encoded prefix",
+			url:                     "/port/%33000/files/a%2Fb",
+			pathPrefix:              "/port/3000",
+			expectedPath:            "/files/a/b",
+			expectedEscapedPath:     "/files/a/b",
+			expectedForwardedPrefix: "/port/3000",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			httpProxy := NewHTTPProxy(HTTPProxyConfig{}, nil)
+
+			req := httptest.NewRequest("GET", tc.url, nil)
+			req.Host = "3000.workspace.localhost"
+
+			req = req.WithContext(context.WithValue(
+				req.Context(),
+				httpProxyRouteContextKey{},
+				httpProxyRoute{
+					targetAddr: "127.0.0.1:3000",
+					pathPrefix: tc.pathPrefix,
+				},
+			))
+
+			director := httpProxy.buildDirector(3000)
+			director(req)
+
+			if req.URL.Scheme != "http" || req.URL.Host != "127.0.0.1:3000" {
+				t.Fatalf("unexpected target %s", req.URL)
+			}
+
+			if req.URL.Path != tc.expectedPath {
+				t.Fatalf("expected path %q, got %q", tc.expectedPath, req.URL.Path)
+			}
+
+			expectedEscapedPath := tc.expectedEscapedPath
+
+			if len(expectedEscapedPath) == 0 {
+				expectedEscapedPath = tc.expectedPath
+			}
+
+			if req.URL.EscapedPath() != expectedEscapedPath {
+				t.Fatalf("expected escaped path %q, got %q", expectedEscapedPath, req.URL.EscapedPath())
+			}
+
+			if req.URL.RawQuery != tc.expectedRawQuery {
+				t.Fatalf("expected query %q, got %q", tc.expectedRawQuery, req.URL.RawQuery)
+			}
+
+			if req.Host != "localhost:3000" {
+				t.Fatalf("expected host \"localhost:3000\", got %q", req.Host)
+			}
+
+			if forwardedHost := req.Header.Get("X-Forwarded-Host"); forwardedHost != "3000.workspace.localhost" {
+				t.Fatalf("unexpected forwarded host %q", forwardedHost)
+			}
+
+			if forwardedProto := req.Header.Get("X-Forwarded-Proto"); forwardedProto != "http" {
+				t.Fatalf("unexpected forwarded proto %q", forwardedProto)
+			}
+
+			if forwardedPrefix := req.Header.Get("X-Forwarded-Prefix"); forwardedPrefix != tc.expectedForwardedPrefix {
+				t.Fatalf("expected forwarded prefix %q, got %q", tc.expectedForwardedPrefix, forwardedPrefix)
+			}
+		})
+	}
+}
+
+func TestHTTPProxyReverseProxies(t *testing.T) {
+	httpProxy := NewHTTPProxy(HTTPProxyConfig{}, nil)
+
+	reverseProxy := httpProxy.getOrCreateReverseProxy(3000)
+
+	if httpProxy.getOrCreateReverseProxy(3000) != reverseProxy {
+		t.Fatal("expected the reverse proxy to be reused for the same port")
+	}
+
+	if httpProxy.getOrCreateReverseProxy(3001) == reverseProxy {
+		t.Fatal("expected one reverse proxy per port")
+	}
+
+	targetServer := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			io.WriteString(w, r.URL.EscapedPath())
+		},
+	))
+
+	defer targetServer.Close()
+
+	// The target is read from the route of each request
+	for i := 0; i < 2; i++ {
+		req := httptest.NewRequest("GET", "/port/3000/files/a%2Fb", nil)
+
+		req = req.WithContext(context.WithValue(
+			req.Context(),
+			httpProxyRouteContextKey{},
+			httpProxyRoute{
+				targetAddr: targetServer.Listener.Addr().String(),
+				pathPrefix: "/port/3000",
+			},
+		))
+
+		res := httptest.NewRecorder()
+		reverseProxy.ServeHTTP(res, req)
+
+		if res.Code != http.StatusOK {
+			t.Fatalf("expected status %d, got %d", http.StatusOK, res.Code)
+		}
+
+		if body := res.Body.String(); body != "/files/a%2Fb" {
+			t.Fatalf("expected path \"/files/a%%2Fb\", got %q", body)
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package network
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+)
+
+var ErrNotContainerLocalAddr = errors.New("not a container address")
+
+// getContainerIPs returns the IP addresses
+// assigned to the container network interfaces.
+func getContainerIPs() ([]net.IP, error) {
+	interfaceAddrs, err := net.InterfaceAddrs()
+
+	if err != nil {
+		return nil, err
+	}
+
+	ips := []net.IP{}
+
+	for _, interfaceAddr := range interfaceAddrs {
+		ipNet, ok := interfaceAddr.(*net.IPNet)
+
+		if !ok {
+			continue
+		}
+
+		ips = append(ips, ipNet.IP)
+	}
+
+	return ips, nil
+}
+
+func getContainerIPNets() ([]*net.IPNet, error) {
+	interfaceAddrs, err := net.InterfaceAddrs()
+
+	if err != nil {
+		return nil, err
+	}
+
+	ipNets := []*net.IPNet{}
+
+	for _, interfaceAddr := range interfaceAddrs {
+		ipNet, ok := interfaceAddr.(*net.IPNet)
+
+		if !ok {
+			continue
+		}
+
+		ipNets = append(ipNets, &net.IPNet{
+			IP:   ipNet.IP.Mask(ipNet.Mask),
+			Mask: ipNet.Mask,
+		})
+	}
+
+	return ipNets, nil
+}
+
+// ResolveContainerLocalAddr resolves the "host:port" address
+// and makes sure that it points to the container itself
+// (loopback or container interface address) so that the agent
+// could not be used to reach arbitrary hosts.
+// The returned address contains the resolved IP.
+func ResolveContainerLocalAddr(
+	ctx context.Context,
+	addr string,
+) (string, error) {
+
+	host, port, err := net.SplitHostPort(addr)
+
+	if err != nil {
+		return "", err
+	}
+
+	ipAddrs, err := net.DefaultResolver.LookupIPAddr(ctx, host)
+
+	if err != nil {
+		return "", err
+	}
+
+	containerIPs, err := getContainerIPs()
+
+	if err != nil {
+		return "", err
+	}
+
+	for _, ipAddr := range ipAddrs {
+		if ipAddr.IP.IsLoopback() || ipAddr.IP.IsUnspecified() {
+			return net.JoinHostPort(ipAddr.IP.String(), port), nil
+		}
+
+		for _, containerIP := range containerIPs {
+			if containerIP.Equal(ipAddr.IP) {
+				return net.JoinHostPort(ipAddr.IP.String(), port), nil
+			}
+		}
+	}
+
+	return "", fmt.Errorf(
+		"%w: \"%s\" does not resolve to a container address",
+		ErrNotContainerLocalAddr,
+		addr,
+	)
+}
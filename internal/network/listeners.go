@@ -0,0 +1,311 @@
+package network
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+)
+
+const (
+	// The delay before retrying to start a proxy that failed,
+	// doubled after each consecutive failure
+	proxyStartRetryInitialBackoff = 500 * time.Millisecond
+	proxyStartRetryMaxBackoff     = 2 * time.Minute
+)
+
+type ListenerState string
+
+const (
+	ListenerStateProxied ListenerState = "proxied"
+	// Bound to all interfaces and reachable without proxy
+	ListenerStateDirect ListenerState = "direct"
+	// Not proxied on purpose (eg: manual port forwarding rule)
+	ListenerStateSkipped ListenerState = "skipped"
+	// The proxy could not bind its port
+	// because of another listener
+	ListenerStateConflict ListenerState = "conflict"
+	ListenerStateFailed   ListenerState = "failed"
+)
+
+// Listener is a snapshot of a listening socket, detected
+// in "/proc/net/tcp", that the proxy manager handles.
+type Listener struct {
+	Addr     string
+	Port     uint64
+	Wildcard bool
+	State    ListenerState
+	// Why the listener is not proxied, if any
+	Reason         string
+	StateChangedAt time.Time
+	// The number of consecutive failures to start the proxy
+	Failures int
+	// When the proxy will be started again (failed state only)
+	NextRetryAt time.Time
+}
+
+type detectedListener struct {
+	listener       localhostListener
+	state          ListenerState
+	reason         string
+	stateChangedAt time.Time
+	// The listeners on the same port when the bind conflict
+	// was diagnosed. The bind is only retried when they change.
+	conflictFingerprint string
+	failures            int
+	nextRetryAt         time.Time
+}
+
+// portListeners contains the IDs of all the listening sockets
+// (whatever their address) grouped by port.
+type portListeners map[uint64][]localhostListenerID
+
+func (p portListeners) fingerprint(port uint64) string {
+	listenerIDs := make([]string, 0, len(p[port]))
+
+	for _, listenerID := range p[port] {
+		listenerIDs = append(listenerIDs, string(listenerID))
+	}
+
+	sort.Strings(listenerIDs)
+
+	return strings.Join(listenerIDs, ",")
+}
+
+func (p portListeners) hasIPv6Listener(port uint64) bool {
+	for _, listenerID := range p[port] {
+		host, _, err := net.SplitHostPort(string(listenerID))
+
+		if err == nil && !isIPv4Addr(host) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Listeners returns the detected loopback and
+// wildcard listeners with their state, ordered by port.
+func (p *ProxyManager) Listeners() []Listener {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	listeners := make([]Listener, 0, len(p.listeners))
+
+	for _, detected := range p.listeners {
+		listeners = append(listeners, Listener{
+			Addr:           detected.listener.listeningAddr,
+			Port:           detected.listener.listeningPort,
+			Wildcard:       detected.listener.wildcard,
+			State:          detected.state,
+			Reason:         detected.reason,
+			StateChangedAt: detected.stateChangedAt,
+			Failures:       detected.failures,
+			NextRetryAt:    detected.nextRetryAt,
+		})
+	}
+
+	sort.Slice(listeners, func(i, j int) bool {
+		if listeners[i].Port != listeners[j].Port {
+			return listeners[i].Port < listeners[j].Port
+		}
+
+		return listeners[i].Addr < listeners[j].Addr
+	})
+
+	return listeners
+}
+
+// Must be called with the mutex held.
+// Returns whether the state (or its reason) has changed.
+func (p *ProxyManager) setListenerState(
+	listenerID localhostListenerID,
+	listener localhostListener,
+	state ListenerState,
+	reason string,
+) bool {
+
+	detected, ok := p.listeners[listenerID]
+
+	if !ok {
+		detected = &detectedListener{
+			listener: listener,
+		}
+
+		p.listeners[listenerID] = detected
+	}
+
+	if ok && detected.state == state && detected.reason == reason {
+		return false
+	}
+
+	detected.state = state
+	detected.reason = reason
+	detected.stateChangedAt = time.Now()
+	detected.conflictFingerprint = ""
+
+	if state != ListenerStateFailed {
+		detected.failures = 0
+		detected.nextRetryAt = time.Time{}
+	}
+
+	return true
+}
+
+// Must be called with the mutex held.
+// The failure is only logged (and published)
+// when its reason differs from the previous one.
+func (p *ProxyManager) recordProxyStartFailure(
+	listenerID localhostListenerID,
+	listener localhostListener,
+	proxy *localhostProxy,
+	err error,
+) {
+
+	reason := err.Error()
+	stateChanged := p.setListenerState(
+		listenerID,
+		listener,
+		ListenerStateFailed,
+		reason,
+	)
+
+	detected := p.listeners[listenerID]
+
+	detected.failures++
+	detected.nextRetryAt = time.Now().Add(
+		computeProxyStartRetryBackoff(detected.failures),
+	)
+
+	if !stateChanged {
+		return
+	}
+
+	logger.Warn(
+		"error when starting proxy",
+		"listener", listenerID,
+		"error", reason,
+		"retry_in", time.Until(detected.nextRetryAt).Round(time.Millisecond),
+	)
+
+	p.publish(ProxyEvent{
+		Type:   ProxyEventTypeFailed,
+		Proxy:  proxy.snapshot(),
+		Reason: reason,
+	})
+}
+
+// Must be called with the mutex held
+func (p *ProxyManager) shouldRetryProxyStart(
+	listenerID localhostListenerID,
+) bool {
+
+	detected, ok := p.listeners[listenerID]
+
+	if !ok || detected.state != ListenerStateFailed {
+		return true
+	}
+
+	return !time.Now().Before(detected.nextRetryAt)
+}
+
+func computeProxyStartRetryBackoff(failures int) time.Duration {
+	backoff := proxyStartRetryInitialBackoff
+
+	for i := 1; i < failures && backoff < proxyStartRetryMaxBackoff; i++ {
+		backoff *= 2
+	}
+
+	if backoff > proxyStartRetryMaxBackoff {
+		return proxyStartRetryMaxBackoff
+	}
+
+	return backoff
+}
+
+// Must be called with the mutex held
+func (p *ProxyManager) diagnoseBindConflict(
+	listenerID localhostListenerID,
+	listener localhostListener,
+	listeners localhostListeners,
+) string {
+
+	for otherListenerID, proxy := range p.proxies {
+		if otherListenerID != listenerID &&
+			proxy.listeningPort == listener.listeningPort {
+
+			return fmt.Sprintf(
+				"port %d is already proxied for %s",
+				listener.listeningPort,
+				proxy.targetAddr,
+			)
+		}
+	}
+
+	// Only the "0.0.0.0" listeners are
+	// proxied (on the IPv6 addresses)
+	if listener.wildcard {
+		return fmt.Sprintf(
+			"%s is only reachable on the IPv4 container addresses, "+
+				"port %d is already bound on an IPv6 container address by another process",
+			listenerID,
+			listener.listeningPort,
+		)
+	}
+
+	wildcardListenerIDs := []string{}
+
+	for otherListenerID, otherListener := range listeners {
+		if otherListener.wildcard &&
+			otherListener.listeningPort == listener.listeningPort {
+
+			wildcardListenerIDs = append(wildcardListenerIDs, string(otherListenerID))
+		}
+	}
+
+	if len(wildcardListenerIDs) > 0 {
+		sort.Strings(wildcardListenerIDs)
+
+		return fmt.Sprintf(
+			"port %d is bound to all interfaces by %s",
+			listener.listeningPort,
+			strings.Join(wildcardListenerIDs, ", "),
+		)
+	}
+
+	return fmt.Sprintf(
+		"port %d is already bound on a container address by another process",
+		listener.listeningPort,
+	)
+}
+
+func isAddrInUseErr(err error) bool {
+	return errors.Is(err, syscall.EADDRINUSE)
+}
+
+func isWildcardIP(ip net.IP) bool {
+	return ip.IsUnspecified()
+}
+
+// dialAddr returns the address used to reach the listener
+// (the loopback address of the same family for the wildcard ones).
+func (l localhostListener) dialAddr() string {
+	dialIP := l.listeningAddr
+
+	if l.wildcard {
+		dialIP = "127.0.0.1"
+
+		if ip := net.ParseIP(l.listeningAddr); ip != nil && ip.To4() == nil {
+			dialIP = "::1"
+		}
+	}
+
+	return net.JoinHostPort(
+		dialIP,
+		strconv.FormatUint(l.listeningPort, 10),
+	)
+}
@@ -0,0 +1,225 @@
+package network
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDiagnoseBindConflict(t *testing.T) {
+	loopbackListener := localhostListener{
+		listeningAddr: "127.0.0.1",
+		listeningPort: 3000,
+	}
+
+	wildcardListener := localhostListener{
+		listeningAddr: "0.0.0.0",
+		listeningPort: 3000,
+		wildcard:      true,
+	}
+
+	testCases := []struct {
+		name           string
+		proxies        map[localhostListenerID]*localhostProxy
+		listenerID     localhostListenerID
+		listener       localhostListener
+		listeners      localhostListeners
+		expectedReason string
+	}{
+		{
+			name: "already proxied",
+			proxies: map[localhostListenerID]*localhostProxy{
+				"[::1]:3000": {
+					listeningPort: 3000,
+					targetAddr:    "[::1]:3000",
+				},
+			},
+			listenerID:     "127.0.0.1:3000",
+			listener:       loopbackListener,
+			listeners:      localhostListeners{"127.0.0.1:3000": loopbackListener},
+			expectedReason: "port 3000 is already proxied for [::1]:3000",
+		},
+		{
+			name:       "wildcard listener",
+			listenerID: "0.0.0.0:3000",
+			listener:   wildcardListener,
+			listeners:  localhostListeners{"0.0.0.0:3000": wildcardListener},
+			expectedReason: "0.0.0.0:3000 is only reachable on the IPv4 container addresses, " +
+				"port 3000 is already bound on an IPv6 container address by another process",
+		},
+		{
+			name:       "other wildcard listeners",
+			listenerID: "127.0.0.1:3000",
+			listener:   loopbackListener,
+			listeners: localhostListeners{
+				"127.0.0.1:3000": loopbackListener,
+				"0.0.0.0:3000":   wildcardListener,
+				"[::]:3000": {
+					listeningAddr: "::",
+					listeningPort: 3000,
+					wildcard:      true,
+				},
+			},
+			expectedReason: "port 3000 is bound to all interfaces by 0.0.0.0:3000, [::]:3000",
+		},
+		{
+			name:           "container address",
+			listenerID:     "127.0.0.1:3000",
+			listener:       loopbackListener,
+			listeners:      localhostListeners{"127.0.0.1:3000": loopbackListener},
+			expectedReason: "port 3000 is already bound on a container address by another process",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			proxyManager := NewProxyManager(NewDefaultProxyManagerConfig())
+
+			if tc.proxies != nil {
+				proxyManager.proxies = tc.proxies
+			}
+
+			reason := proxyManager.diagnoseBindConflict(
+				tc.listenerID,
+				tc.listener,
+				tc.listeners,
+			)
+
+			if reason != tc.expectedReason {
+				t.Fatalf("expected %q, got %q", tc.expectedReason, reason)
+			}
+		})
+	}
+}
+
+func TestLocalhostListenerDialAddr(t *testing.T) {
+	testCases := []struct {
+		name             string
+		listener         localhostListener
+		expectedDialAddr string
+	}{
+		{
+			name: "IPv4 loopback",
+			listener: localhostListener{
+				listeningAddr: "127.0.0.2",
+				listeningPort: 3000,
+			},
+			expectedDialAddr: "127.0.0.2:3000",
+		},
+		{
+			name: "IPv6 loopback",
+			listener: localhostListener{
+				listeningAddr: "::1",
+				listeningPort: 3000,
+			},
+			expectedDialAddr: "[::1]:3000",
+		},
+		{
+			name: "IPv4 wildcard",
+			listener: localhostListener{
+				listeningAddr: "0.0.0.0",
+				listeningPort: 3000,
+				wildcard:      true,
+			},
+			expectedDialAddr: "127.0.0.1:3000",
+		},
+		{
+			name: "IPv6 wildcard",
+			listener: localhostListener{
+				listeningAddr: "::",
+				listeningPort: 3000,
+				wildcard:      true,
+			},
+			expectedDialAddr: "[::1]:3000",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if dialAddr := tc.listener.dialAddr(); dialAddr != tc.expectedDialAddr {
+				t.Fatalf("expected %q, got %q", tc.expectedDialAddr, dialAddr)
+			}
+		})
+	}
+}
+
+func TestPortListenersHasIPv6Listener(t *testing.T) {
+	listenersByPort := portListeners{
+		3000: {"0.0.0.0:3000", "[::]:3000"},
+		4000: {"0.0.0.0:4000", "127.0.0.1:4000"},
+		5000: {"0.0.0.0:5000", "[::1]:5000"},
+	}
+
+	testCases := []struct {
+		name            string
+		port            uint64
+		expectedHasIPv6 bool
+	}{
+		{
+			name:            "IPv6 wildcard listener",
+			port:            3000,
+			expectedHasIPv6: true,
+		},
+		{
+			name:            "IPv4 listeners only",
+			port:            4000,
+			expectedHasIPv6: false,
+		},
+		{
+			name:            "IPv6 loopback listener",
+			port:            5000,
+			expectedHasIPv6: true,
+		},
+		{
+			name:            "no listeners",
+			port:            6000,
+			expectedHasIPv6: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if hasIPv6 := listenersByPort.hasIPv6Listener(tc.port); hasIPv6 != tc.expectedHasIPv6 {
+				t.Fatalf("expected %t, got %t", tc.expectedHasIPv6, hasIPv6)
+			}
+		})
+	}
+}
+
+func TestComputeProxyStartRetryBackoff(t *testing.T) {
+	testCases := []struct {
+		name            string
+		failures        int
+		expectedBackoff time.Duration
+	}{
+		{
+			name:            "first failure",
+			failures:        1,
+			expectedBackoff: 500 * time.Millisecond,
+		},
+		{
+			name:            "doubled",
+			failures:        3,
+			expectedBackoff: 2 * time.Second,
+		},
+		{
+			name:            "capped",
+			failures:        10,
+			expectedBackoff: 2 * time.Minute,
+		},
+		{
+			name:            "still capped",
+			failures:        1000,
+			expectedBackoff: 2 * time.Minute,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			backoff := computeProxyStartRetryBackoff(tc.failures)
+
+			if backoff != tc.expectedBackoff {
+				t.Fatalf("expected %s, got %s", tc.expectedBackoff, backoff)
+			}
+		})
+	}
+}
@@ -1,12 +1,15 @@
 package network
 
 import (
-	"fmt"
+	"context"
+	"crypto/tls"
 	"io"
-	"log"
 	"net"
+	"strconv"
+	"time"
 
-	"github.com/yolo-sh/agent-container/constants"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/yolo-sh/agent-container/internal/metrics"
 )
 
 type localhostListenerID string
@@ -14,19 +17,77 @@ type localhostListenerID string
 type localhostListener struct {
 	listeningPort uint64
 	listeningAddr string
+	// Bound to all interfaces ("0.0.0.0" or "::")
+	wildcard bool
 }
 
 type localhostListeners map[localhostListenerID]localhostListener
 
 type localhostProxy struct {
-	listeningPort uint64
-	targetAddr    string
-	doneChan      chan struct{}
+	source         ProxySource
+	listeningAddrs []string
+	listeningPort  uint64
+	targetNetwork  string
+	targetAddr     string
+	tls            bool
+	// Prepend a PROXY protocol v2 header
+	// to the traffic sent to the target
+	sendProxyProtocol bool
+	connLimiter       *proxyConnLimiter
+	startedAt         time.Time
+	doneChan          chan struct{}
+	netProxies        []net.Listener
+}
+
+// close stops accepting connections and closes the
+// listeners before returning so that the port
+// could be bound again right away.
+func (l *localhostProxy) close() {
+	close(l.doneChan)
+
+	for _, netProxy := range l.netProxies {
+		if err := netProxy.Close(); err != nil {
+			logger.Warn(
+				"error when closing proxy",
+				"target_addr", l.targetAddr,
+				"error", err,
+			)
+		}
+	}
+}
+
+func (l localhostProxy) snapshot() Proxy {
+	return Proxy{
+		Source:            l.source,
+		ListeningAddrs:    l.listeningAddrs,
+		ListeningPort:     l.listeningPort,
+		TargetNetwork:     l.targetNetwork,
+		TargetAddr:        l.targetAddr,
+		TLS:               l.tls,
+		SendProxyProtocol: l.sendProxyProtocol,
+		StartedAt:         l.startedAt,
+	}
+}
+
+// ForwardingConfig controls how the connections
+// accepted by the localhost proxies are forwarded.
+type ForwardingConfig struct {
+	// How long to wait for the other direction
+	// once one side has closed its write half.
+	// Zero waits until the other direction is done
+	// (or idle, see IdleTimeout).
+	LingerTimeout time.Duration
+	// Zero disables the idle timeout.
+	// Reads and writes both count as activity.
+	IdleTimeout time.Duration
+	// A negative value disables TCP keep-alives
+	KeepAlivePeriod time.Duration
 }
 
-var localhostProxies = map[localhostListenerID]localhostProxy{}
+func (p *ProxyManager) ReconcileLocalhostProxiesState() error {
+	reconcileTimer := prometheus.NewTimer(metrics.ReconcileDuration)
+	defer reconcileTimer.ObserveDuration()
 
-func ReconcileLocalhostProxiesState() error {
 	tcpConns, err := getOpenedTCPConns()
 
 	if err != nil {
@@ -34,108 +95,322 @@ func ReconcileLocalhostProxiesState() error {
 	}
 
 	listeners := localhostListeners{}
+	listenersByPort := portListeners{}
 
 	for _, conn := range tcpConns {
 		if conn.St != uint64(tcpConnStatusListening) {
 			continue
 		}
 
-		if !conn.LocalAddr.IsLoopback() {
-			continue
-		}
-
 		listeningAddr := conn.LocalAddr.String()
 
-		if conn.LocalAddr.To4() == nil { // IPv6
-			listeningAddr = "[" + listeningAddr + "]"
-		}
-
-		listenerAddrAndPort := fmt.Sprintf(
-			"%s:%d",
+		listenerAddrAndPort := net.JoinHostPort(
 			listeningAddr,
-			conn.LocalPort,
+			strconv.FormatUint(conn.LocalPort, 10),
+		)
+
+		listenerID := localhostListenerID(listenerAddrAndPort)
+
+		listenersByPort[conn.LocalPort] = append(
+			listenersByPort[conn.LocalPort],
+			listenerID,
 		)
 
-		listeners[localhostListenerID(listenerAddrAndPort)] = localhostListener{
+		wildcard := isWildcardIP(conn.LocalAddr)
+
+		if !conn.LocalAddr.IsLoopback() && !wildcard {
+			continue
+		}
+
+		listeners[listenerID] = localhostListener{
 			listeningAddr: listeningAddr,
 			listeningPort: conn.LocalPort,
+			wildcard:      wildcard,
 		}
 	}
 
-	reconcileLocalhostProxiesState(listeners)
+	p.reconcileLocalhostProxiesState(listeners, listenersByPort)
 
 	return nil
 }
 
-func reconcileLocalhostProxiesState(listeners localhostListeners) {
-	for listenerID, proxy := range localhostProxies {
+func (p *ProxyManager) reconcileLocalhostProxiesState(
+	listeners localhostListeners,
+	listenersByPort portListeners,
+) {
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	// Stopped between the poll and the lock
+	select {
+	case <-p.stopChan:
+		return
+	default:
+	}
+
+	for listenerID := range p.proxies {
+		if _, listenerExists := listeners[listenerID]; listenerExists {
+			continue
+		}
+
+		p.stopProxy(listenerID)
+	}
+
+	for listenerID := range p.listeners {
 		if _, listenerExists := listeners[listenerID]; listenerExists {
 			continue
 		}
 
-		close(proxy.doneChan)
-		delete(localhostProxies, listenerID)
+		delete(p.listeners, listenerID)
 	}
 
+	// Resolved once per pass, when needed (see below)
+	var bindAddrs []string
+	var bindAddrsErr error
+	bindAddrsResolved := false
+
 	for listenerID, listener := range listeners {
-		if _, proxyExists := localhostProxies[listenerID]; proxyExists {
+		if _, proxyExists := p.proxies[listenerID]; proxyExists {
+			p.setListenerState(listenerID, listener, ListenerStateProxied, "")
 			continue
 		}
 
-		proxy := localhostProxy{
-			listeningPort: listener.listeningPort,
-			targetAddr:    listener.listeningAddr,
-			doneChan:      make(chan struct{}),
+		// Manual port forwarding rules take precedence
+		// (even when their proxy is waiting to be restarted)
+		if _, ruleExists := p.rules[listener.listeningPort]; ruleExists {
+			p.setListenerState(
+				listenerID,
+				listener,
+				ListenerStateSkipped,
+				"port forwarded by a manual port forwarding rule",
+			)
+
+			continue
 		}
 
-		netProxy, err := startLocalhostProxy(proxy)
+		if _, portExcluded := p.excludedPorts[listener.listeningPort]; portExcluded {
+			p.setListenerState(
+				listenerID,
+				listener,
+				ListenerStateSkipped,
+				"port reserved by a reverse tunnel",
+			)
 
-		if err != nil {
-			log.Printf(
-				"error when starting proxy for %s:%d: %v",
-				listener.listeningAddr,
+			continue
+		}
+
+		// The "0.0.0.0" listeners are not reachable over IPv6.
+		// They are proxied on the IPv6 container addresses
+		// unless another listener (eg: "::") uses the IPv6 port.
+		if listener.wildcard && isIPv4Addr(listener.listeningAddr) &&
+			listenersByPort.hasIPv6Listener(listener.listeningPort) {
+
+			p.setListenerState(
+				listenerID,
+				listener,
+				ListenerStateDirect,
+				"bound to all interfaces, reachable on the IPv4 container addresses "+
+					"(the IPv6 port is used by another listener)",
+			)
+
+			continue
+		}
+
+		portFingerprint := listenersByPort.fingerprint(listener.listeningPort)
+
+		// The conflicting listeners are still there
+		if detected, ok := p.listeners[listenerID]; ok &&
+			detected.state == ListenerStateConflict &&
+			detected.conflictFingerprint == portFingerprint {
+
+			continue
+		}
+
+		if !p.shouldRetryProxyStart(listenerID) {
+			continue
+		}
+
+		if !bindAddrsResolved {
+			bindAddrs, bindAddrsErr = p.resolveBindAddrs()
+			bindAddrsResolved = true
+		}
+
+		listeningAddrs := proxyBindAddrs(listener, bindAddrs)
+
+		// Already reachable on all the container addresses
+		// (eg: "::" listeners, that are dual-stack)
+		if listener.wildcard && len(listeningAddrs) == 0 {
+			p.setListenerState(
+				listenerID,
+				listener,
+				ListenerStateDirect,
+				"bound to all interfaces, reachable on the container addresses",
+			)
+
+			continue
+		}
+
+		proxy := &localhostProxy{
+			source:         ProxySourceAuto,
+			listeningAddrs: listeningAddrs,
+			listeningPort:  listener.listeningPort,
+			targetNetwork:  "tcp",
+			targetAddr:     listener.dialAddr(),
+			sendProxyProtocol: p.isSendProxyProtocolPort(
 				listener.listeningPort,
-				err,
+			),
+			connLimiter: p.newProxyConnLimiter(listener.listeningPort),
+			startedAt:   time.Now(),
+			doneChan:    make(chan struct{}),
+		}
+
+		var netProxies []net.Listener
+		err := bindAddrsErr
+
+		if err == nil {
+			netProxies, err = p.startLocalhostProxy(proxy)
+		}
+
+		if err != nil && isAddrInUseErr(err) {
+			reason := p.diagnoseBindConflict(listenerID, listener, listeners)
+
+			stateChanged := p.setListenerState(
+				listenerID,
+				listener,
+				ListenerStateConflict,
+				reason,
 			)
 
+			p.listeners[listenerID].conflictFingerprint = portFingerprint
+
+			if stateChanged {
+				logger.Warn(
+					"proxy bind conflict",
+					"listener", listenerID,
+					"reason", reason,
+				)
+
+				p.publish(ProxyEvent{
+					Type:   ProxyEventTypeFailed,
+					Proxy:  proxy.snapshot(),
+					Reason: reason,
+				})
+			}
+
+			continue
+		}
+
+		if err != nil {
+			p.recordProxyStartFailure(listenerID, listener, proxy, err)
 			continue
 		}
 
-		localhostProxies[listenerID] = proxy
+		p.proxies[listenerID] = proxy
+		p.setListenerState(listenerID, listener, ListenerStateProxied, "")
 
-		go handleLocalhostProxyConn(
-			netProxy,
+		metrics.ActiveProxies.Inc()
+
+		p.publish(ProxyEvent{
+			Type:  ProxyEventTypeStarted,
+			Proxy: proxy.snapshot(),
+		})
+
+		p.handleLocalhostProxyConns(
+			netProxies,
 			proxy,
 		)
 	}
+
+	p.retryFailedManualProxies()
+	p.pruneStats()
 }
 
-func startLocalhostProxy(proxy localhostProxy) (net.Listener, error) {
-	return net.Listen(
-		"tcp",
-		fmt.Sprintf(
-			"%s:%d",
-			constants.DockerContainerIPAddress,
-			proxy.listeningPort,
-		),
-	)
+// Must be called with the mutex held
+func (p *ProxyManager) pruneStats() {
+	forwardedPorts := map[uint64]struct{}{}
+
+	for _, proxy := range p.proxies {
+		forwardedPorts[proxy.listeningPort] = struct{}{}
+	}
+
+	for port := range p.manualProxies {
+		forwardedPorts[port] = struct{}{}
+	}
+
+	p.stats.prune(forwardedPorts)
 }
 
-func handleLocalhostProxyConn(netProxy net.Listener, proxy localhostProxy) {
-	go func() {
-		<-proxy.doneChan
+// startLocalhostProxy listens on all the proxy addresses.
+// Either all the listeners are returned or none.
+func (p *ProxyManager) startLocalhostProxy(
+	proxy *localhostProxy,
+) ([]net.Listener, error) {
 
-		if err := netProxy.Close(); err != nil {
-			log.Printf(
-				"error when closing proxy for %s:%d: %v",
-				proxy.targetAddr,
-				proxy.listeningPort,
-				err,
+	listenConfig := net.ListenConfig{
+		KeepAlive: p.config.Forwarding.KeepAlivePeriod,
+	}
+
+	netProxies := []net.Listener{}
+
+	for _, listeningAddr := range proxy.listeningAddrs {
+		netProxy, err := listenConfig.Listen(
+			context.Background(),
+			"tcp",
+			net.JoinHostPort(
+				listeningAddr,
+				strconv.FormatUint(proxy.listeningPort, 10),
+			),
+		)
+
+		if err != nil {
+			for _, startedNetProxy := range netProxies {
+				startedNetProxy.Close()
+			}
+
+			return nil, err
+		}
+
+		// The upstream header precedes the TLS handshake
+		if p.config.AcceptProxyProtocol {
+			netProxy = newProxyProtocolListener(netProxy)
+		}
+
+		if proxy.tls {
+			netProxy = tls.NewListener(
+				netProxy,
+				p.config.WorkspaceCA.TLSConfig(),
 			)
 		}
-	}()
+
+		netProxies = append(netProxies, netProxy)
+	}
+
+	return netProxies, nil
+}
+
+func (p *ProxyManager) handleLocalhostProxyConns(
+	netProxies []net.Listener,
+	proxy *localhostProxy,
+) {
+
+	proxy.netProxies = netProxies
+
+	for _, netProxy := range netProxies {
+		p.handleLocalhostProxyConn(netProxy, proxy)
+	}
+}
+
+func (p *ProxyManager) handleLocalhostProxyConn(
+	netProxy net.Listener,
+	proxy *localhostProxy,
+) {
 
 	go func() {
+		// Prevents a busy loop when the
+		// accept errors persist (eg: EMFILE)
+		var acceptRetryDelay time.Duration
+
 		for {
 			proxyConn, err := netProxy.Accept()
 
@@ -144,97 +419,271 @@ func handleLocalhostProxyConn(netProxy net.Listener, proxy localhostProxy) {
 				case <-proxy.doneChan:
 					return
 				default:
-					log.Printf(
-						"error when accepting connection on proxy for %s:%d: %v",
-						proxy.targetAddr,
-						proxy.listeningPort,
-						err,
+					metrics.ProxyConnections.WithLabelValues(
+						metrics.ProxyConnStatusFailed,
+					).Inc()
+
+					logger.Warn(
+						"error when accepting connection on proxy",
+						"target_addr", proxy.targetAddr,
+						"error", err,
 					)
 
+					acceptRetryDelay = computeAcceptRetryDelay(acceptRetryDelay)
+					time.Sleep(acceptRetryDelay)
+
 					continue
 				}
 			}
 
-			localConn, err := connectToLocalhostAddr(proxy)
+			acceptRetryDelay = 0
+
+			releaseConn, rejectReason := proxy.connLimiter.acquire()
+
+			if len(rejectReason) > 0 {
+				metrics.ProxyConnectionsRejected.WithLabelValues(
+					strconv.FormatUint(proxy.listeningPort, 10),
+					string(rejectReason),
+				).Inc()
+
+				p.stats.connRejected(proxy.listeningPort)
+
+				proxyConn.Close()
+
+				continue
+			}
+
+			localConn, err := p.connectToLocalhostAddr(proxy)
 
 			if err != nil {
-				log.Printf(
-					"error when connecting to %s:%d: %v",
-					proxy.targetAddr,
-					proxy.listeningPort,
-					err,
+				releaseConn()
+
+				metrics.ProxyConnections.WithLabelValues(
+					metrics.ProxyConnStatusFailed,
+				).Inc()
+
+				p.stats.connFailed(proxy.listeningPort)
+
+				logger.Warn(
+					"error when connecting to proxy target",
+					"target_addr", proxy.targetAddr,
+					"error", err,
 				)
 
 				if err := proxyConn.Close(); err != nil {
-					log.Printf(
-						"error when closing proxy connection: %v",
-						err,
+					logger.Warn(
+						"error when closing proxy connection",
+						"error", err,
 					)
 				}
 
 				continue
 			}
 
-			go forwardProxyConnToLocalhost(
-				proxyConn,
-				localConn,
-			)
+			metrics.ProxyConnections.WithLabelValues(
+				metrics.ProxyConnStatusAccepted,
+			).Inc()
+
+			go func() {
+				defer releaseConn()
+
+				p.forwardProxyConnToLocalhost(
+					proxy,
+					proxyConn,
+					localConn,
+				)
+			}()
 		}
 	}()
 }
 
-func connectToLocalhostAddr(proxy localhostProxy) (net.Conn, error) {
-	return net.Dial(
-		"tcp",
-		fmt.Sprintf(
-			"%s:%d",
-			proxy.targetAddr,
-			proxy.listeningPort,
-		),
+func computeAcceptRetryDelay(previousDelay time.Duration) time.Duration {
+	if previousDelay == 0 {
+		return 5 * time.Millisecond
+	}
+
+	if previousDelay*2 > time.Second {
+		return time.Second
+	}
+
+	return previousDelay * 2
+}
+
+func (p *ProxyManager) connectToLocalhostAddr(
+	proxy *localhostProxy,
+) (net.Conn, error) {
+
+	dialer := net.Dialer{
+		KeepAlive: p.config.Forwarding.KeepAlivePeriod,
+	}
+
+	return dialer.Dial(
+		proxy.targetNetwork,
+		proxy.targetAddr,
 	)
 }
 
-func forwardProxyConnToLocalhost(
+// forwardProxyConnToLocalhost forwards traffic in both directions
+// and propagates half-closes: when one side stops writing,
+// the write half of the other connection is closed and
+// the remaining direction is given "LingerTimeout"
+// to complete before both connections are closed.
+func (p *ProxyManager) forwardProxyConnToLocalhost(
+	proxy *localhostProxy,
 	proxyConn net.Conn,
 	localConn net.Conn,
 ) error {
 
-	defer func() {
-		proxyConn.Close()
-		localConn.Close()
-	}()
+	// Reads the PROXY protocol header, if any
+	connAccounting := p.stats.connOpened(
+		proxy.listeningPort,
+		proxyConn.RemoteAddr().String(),
+	)
+
+	if proxy.sendProxyProtocol {
+		_, err := localConn.Write(buildProxyProtocolV2Header(
+			proxyConn.RemoteAddr(),
+			proxyConn.LocalAddr(),
+		))
+
+		if err != nil {
+			proxyConn.Close()
+			localConn.Close()
+
+			p.stats.connClosed(
+				connAccounting,
+				ProxyConnCloseReasonError,
+				err,
+			)
+
+			return err
+		}
+	}
+
+	listeningPort := strconv.FormatUint(proxy.listeningPort, 10)
+	idleTracker := newConnIdleTracker(p.config.Forwarding.IdleTimeout)
 
 	proxyConnChan := make(chan error, 1)
 	localConnChan := make(chan error, 1)
 
-	// Forward proxy -> local
+	// Forward local -> proxy
 	go func() {
-		_, err := io.Copy(proxyConn, localConn)
-		proxyConnChan <- err
+		proxyConnChan <- forwardConnHalf(
+			newBytesCountingWriter(
+				proxyConn,
+				metrics.ProxyBytesForwarded.WithLabelValues(
+					listeningPort,
+					metrics.ProxyDirectionOutbound,
+				),
+				connAccounting.addBytesOutbound,
+			),
+			proxyConn,
+			localConn,
+			idleTracker,
+		)
 	}()
 
-	// Forward local -> proxy
+	// Forward proxy -> local
 	go func() {
-		_, err := io.Copy(localConn, proxyConn)
-		localConnChan <- err
+		localConnChan <- forwardConnHalf(
+			newBytesCountingWriter(
+				localConn,
+				metrics.ProxyBytesForwarded.WithLabelValues(
+					listeningPort,
+					metrics.ProxyDirectionInbound,
+				),
+				connAccounting.addBytesInbound,
+			),
+			localConn,
+			proxyConn,
+			idleTracker,
+		)
 	}()
 
+	var closeReason ProxyConnCloseReason
+	var closeErr error
+	var remainingChan chan error
+
 	select {
-	case proxyConnErr := <-proxyConnChan:
-		if proxyConnErr != nil {
-			fmt.Printf(
-				"error during proxy connection forwarding: %v",
-				proxyConnErr,
-			)
+	case closeErr = <-proxyConnChan:
+		closeReason = ProxyConnCloseReasonServiceClosed
+		remainingChan = localConnChan
+	case closeErr = <-localConnChan:
+		closeReason = ProxyConnCloseReasonHostClosed
+		remainingChan = proxyConnChan
+	}
+
+	if closeErr == nil && p.config.Forwarding.LingerTimeout <= 0 {
+		closeErr = <-remainingChan
+		remainingChan = nil
+	}
+
+	if closeErr == nil && remainingChan != nil {
+		lingerTimer := time.NewTimer(p.config.Forwarding.LingerTimeout)
+
+		select {
+		case closeErr = <-remainingChan:
+			remainingChan = nil
+		case <-lingerTimer.C:
+			closeReason = ProxyConnCloseReasonLingerTimeout
 		}
-		return proxyConnErr
-	case localConnErr := <-localConnChan:
-		if localConnErr != nil {
-			fmt.Printf(
-				"error during local connection forwarding: %v",
-				localConnErr,
-			)
+
+		lingerTimer.Stop()
+	}
+
+	if closeErr != nil {
+		closeReason = ProxyConnCloseReasonError
+
+		if isConnTimeoutErr(closeErr) {
+			closeReason = ProxyConnCloseReasonIdleTimeout
 		}
-		return localConnErr
 	}
+
+	proxyConn.Close()
+	localConn.Close()
+
+	// Make sure that the remaining direction
+	// has returned before reporting the stats
+	if remainingChan != nil {
+		<-remainingChan
+	}
+
+	p.stats.connClosed(
+		connAccounting,
+		closeReason,
+		closeErr,
+	)
+
+	return closeErr
+}
+
+// bytesCountingWriter reports the number of bytes
+// written to the underlying writer as they are written
+// (so that long-lived connections are accounted for).
+type bytesCountingWriter struct {
+	writer  io.Writer
+	counter prometheus.Counter
+	onWrite func(n int)
+}
+
+func newBytesCountingWriter(
+	writer io.Writer,
+	counter prometheus.Counter,
+	onWrite func(n int),
+) *bytesCountingWriter {
+
+	return &bytesCountingWriter{
+		writer:  writer,
+		counter: counter,
+		onWrite: onWrite,
+	}
+}
+
+func (b *bytesCountingWriter) Write(p []byte) (int, error) {
+	n, err := b.writer.Write(p)
+
+	b.counter.Add(float64(n))
+	b.onWrite(n)
+
+	return n, err
 }
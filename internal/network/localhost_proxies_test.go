@@ -0,0 +1,339 @@
+package network
+
+import (
+	"bytes"
+	"io"
+	"net"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestReconcileIPv4WildcardListener(t *testing.T) {
+	ipv6Listener, err := net.Listen("tcp", "[::1]:0")
+
+	if err != nil {
+		t.Skipf("IPv6 not available: %v", err)
+	}
+
+	ipv6Listener.Close()
+
+	// Reached using "127.0.0.1" like a "0.0.0.0" listener
+	echoServerAddr := startTestEchoServer(t)
+
+	_, portAsString, err := net.SplitHostPort(echoServerAddr)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	port, err := strconv.ParseUint(portAsString, 10, 64)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	listenerID := localhostListenerID(net.JoinHostPort("0.0.0.0", portAsString))
+	ipv6WildcardListenerID := localhostListenerID(net.JoinHostPort("::", portAsString))
+
+	listeners := localhostListeners{
+		listenerID: {
+			listeningAddr: "0.0.0.0",
+			listeningPort: port,
+			wildcard:      true,
+		},
+	}
+
+	testCases := []struct {
+		name              string
+		bindAddrs         []string
+		listenersByPort   portListeners
+		expectedState     ListenerState
+		expectedProxyAddr string
+	}{
+		{
+			name:              "IPv6 bind address",
+			bindAddrs:         []string{"127.0.0.1", "::1"},
+			listenersByPort:   portListeners{port: {listenerID}},
+			expectedState:     ListenerStateProxied,
+			expectedProxyAddr: net.JoinHostPort("::1", portAsString),
+		},
+		{
+			name:            "IPv4 bind address only",
+			bindAddrs:       []string{"127.0.0.1"},
+			listenersByPort: portListeners{port: {listenerID}},
+			expectedState:   ListenerStateDirect,
+		},
+		{
+			name:            "IPv6 wildcard listener on the same port",
+			bindAddrs:       []string{"127.0.0.1", "::1"},
+			listenersByPort: portListeners{port: {listenerID, ipv6WildcardListenerID}},
+			expectedState:   ListenerStateDirect,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			config := NewDefaultProxyManagerConfig()
+			config.BindAddrs = tc.bindAddrs
+			config.RulesFilePath = ""
+
+			proxyManager := NewProxyManager(config)
+
+			proxyManager.reconcileLocalhostProxiesState(listeners, tc.listenersByPort)
+
+			// Stops the proxies
+			defer proxyManager.reconcileLocalhostProxiesState(
+				localhostListeners{},
+				portListeners{},
+			)
+
+			detectedListeners := proxyManager.Listeners()
+
+			if len(detectedListeners) != 1 {
+				t.Fatalf("expected one listener, got %+v", detectedListeners)
+			}
+
+			if detectedListeners[0].State != tc.expectedState {
+				t.Fatalf(
+					"expected the state %q, got %q (%s)",
+					tc.expectedState,
+					detectedListeners[0].State,
+					detectedListeners[0].Reason,
+				)
+			}
+
+			if len(tc.expectedProxyAddr) > 0 {
+				assertTestEcho(t, tc.expectedProxyAddr)
+			}
+		})
+	}
+}
+
+func TestForwardProxyConnToLocalhost(t *testing.T) {
+	response := bytes.Repeat([]byte("pong"), 256*1024)
+
+	testCases := []struct {
+		name                string
+		forwarding          ForwardingConfig
+		clientWrites        bool
+		serviceResponds     bool
+		expectedResponse    []byte
+		expectedCloseReason ProxyConnCloseReason
+	}{
+		{
+			name: "half-close",
+			forwarding: ForwardingConfig{
+				LingerTimeout: 5 * time.Second,
+			},
+			clientWrites:        true,
+			serviceResponds:     true,
+			expectedResponse:    response,
+			expectedCloseReason: ProxyConnCloseReasonHostClosed,
+		},
+		{
+			name: "half-close without linger timeout",
+			forwarding: ForwardingConfig{
+				LingerTimeout: 0,
+			},
+			clientWrites:        true,
+			serviceResponds:     true,
+			expectedResponse:    response,
+			expectedCloseReason: ProxyConnCloseReasonHostClosed,
+		},
+		{
+			name: "linger timeout",
+			forwarding: ForwardingConfig{
+				LingerTimeout: 50 * time.Millisecond,
+			},
+			clientWrites:        true,
+			serviceResponds:     false,
+			expectedResponse:    []byte{},
+			expectedCloseReason: ProxyConnCloseReasonLingerTimeout,
+		},
+		{
+			name: "idle timeout",
+			forwarding: ForwardingConfig{
+				IdleTimeout: 50 * time.Millisecond,
+			},
+			clientWrites:        false,
+			serviceResponds:     false,
+			expectedResponse:    []byte{},
+			expectedCloseReason: ProxyConnCloseReasonIdleTimeout,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			config := newTestProxyManager().config
+			config.Forwarding = tc.forwarding
+
+			proxyManager := NewProxyManager(config)
+
+			serviceResponds := tc.serviceResponds
+			serviceDoneChan := make(chan struct{})
+			defer close(serviceDoneChan)
+
+			// The service reads the request until the host
+			// half-closes the connection then sends the response,
+			// if any, or keeps the connection open
+			serviceAddr := startTestService(t, func(conn net.Conn) {
+				if _, err := io.Copy(io.Discard, conn); err != nil {
+					return
+				}
+
+				if !serviceResponds {
+					<-serviceDoneChan
+					return
+				}
+
+				conn.Write(response)
+			})
+
+			clientConn, proxyConn := newTestConnPair(t)
+
+			localConn, err := net.Dial("tcp", serviceAddr)
+
+			if err != nil {
+				t.Fatalf("unexpected error %v", err)
+			}
+
+			proxy := &localhostProxy{
+				listeningPort: 3000,
+				targetNetwork: "tcp",
+				targetAddr:    serviceAddr,
+			}
+
+			forwardErrChan := make(chan error, 1)
+
+			go func() {
+				forwardErrChan <- proxyManager.forwardProxyConnToLocalhost(
+					proxy,
+					proxyConn,
+					localConn,
+				)
+			}()
+
+			if tc.clientWrites {
+				if _, err := clientConn.Write([]byte("ping")); err != nil {
+					t.Fatalf("unexpected error %v", err)
+				}
+
+				if err := clientConn.(*net.TCPConn).CloseWrite(); err != nil {
+					t.Fatalf("unexpected error %v", err)
+				}
+			}
+
+			clientConn.SetReadDeadline(time.Now().Add(5 * time.Second))
+
+			// Until the proxy closes the connection
+			receivedResponse, err := io.ReadAll(clientConn)
+
+			if err != nil {
+				t.Fatalf("unexpected error %v", err)
+			}
+
+			if !bytes.Equal(receivedResponse, tc.expectedResponse) {
+				t.Fatalf(
+					"expected a %d bytes response, got %d bytes",
+					len(tc.expectedResponse),
+					len(receivedResponse),
+				)
+			}
+
+			select {
+			case <-forwardErrChan:
+			case <-time.After(5 * time.Second):
+				t.Fatal("expected the forwarding to return")
+			}
+
+			stats := proxyManager.Stats()
+
+			if len(stats) != 1 || len(stats[0].RecentConns) != 1 {
+				t.Fatalf("expected one closed connection, got %+v", stats)
+			}
+
+			connStats := stats[0].RecentConns[0]
+
+			if connStats.CloseReason != tc.expectedCloseReason {
+				t.Fatalf(
+					"expected the close reason %q, got %q (%s)",
+					tc.expectedCloseReason,
+					connStats.CloseReason,
+					connStats.CloseError,
+				)
+			}
+
+			if connStats.BytesOutbound != uint64(len(tc.expectedResponse)) {
+				t.Fatalf(
+					"expected %d outbound bytes, got %d",
+					len(tc.expectedResponse),
+					connStats.BytesOutbound,
+				)
+			}
+		})
+	}
+}
+
+// startTestService returns the address of a loopback
+// server that handles each connection using "handleConn"
+func startTestService(t *testing.T, handleConn func(conn net.Conn)) string {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() { listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+
+			if err != nil {
+				return
+			}
+
+			go func() {
+				defer conn.Close()
+				handleConn(conn)
+			}()
+		}
+	}()
+
+	return listener.Addr().String()
+}
+
+// newTestConnPair returns the two sides of a loopback
+// TCP connection: the client and the accepted one
+func newTestConnPair(t *testing.T) (net.Conn, net.Conn) {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer listener.Close()
+
+	clientConn, err := net.Dial("tcp", listener.Addr().String())
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() { clientConn.Close() })
+
+	acceptedConn, err := listener.Accept()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() { acceptedConn.Close() })
+
+	return clientConn, acceptedConn
+}
@@ -0,0 +1,403 @@
+package network
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/yolo-sh/agent-container/entities"
+	"github.com/yolo-sh/agent-container/internal/metrics"
+)
+
+var (
+	ErrInvalidPortForwardingRule  = errors.New("invalid port forwarding rule")
+	ErrPortForwardingRuleNotFound = errors.New("port forwarding rule not found")
+)
+
+// ExposePort forwards the external port "rule.ExternalPort"
+// on the container IP address to "rule.TargetAddr",
+// replacing any existing rule for the same external port.
+// The target must be a container address or a Unix socket
+// located under "UnixSocketsDirs" (see DialTCP and DialSocket).
+// The rule is persisted, with the target address resolved,
+// and restored by Start.
+// Auto-detected proxies listening on the same port are stopped.
+func (p *ProxyManager) ExposePort(rule entities.PortForwardingRule) error {
+	if err := validatePortForwardingRule(rule); err != nil {
+		return err
+	}
+
+	if err := p.ensureTLSTerminationEnabled(rule); err != nil {
+		return err
+	}
+
+	targetAddr, err := p.resolvePortForwardingRuleTarget(rule)
+
+	if err != nil {
+		return err
+	}
+
+	rule.TargetAddr = targetAddr
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	oldRule, oldRuleExists := p.rules[rule.ExternalPort]
+
+	p.stopManualProxy(rule.ExternalPort)
+
+	for listenerID, proxy := range p.proxies {
+		if proxy.listeningPort == rule.ExternalPort {
+			p.stopProxy(listenerID)
+		}
+	}
+
+	err = p.startManualProxy(rule)
+
+	if err != nil {
+		// The stopped auto-detected proxies
+		// are restarted by the reconciliation
+		if oldRuleExists {
+			p.restartManualProxy(oldRule)
+		}
+
+		return err
+	}
+
+	delete(p.failedRules, rule.ExternalPort)
+	p.rules[rule.ExternalPort] = rule
+
+	return p.savePortForwardingRules()
+}
+
+// UnexposePort stops forwarding the external port
+// and removes the corresponding persisted rule.
+// Auto-detected proxies will be able to use the port again.
+func (p *ProxyManager) UnexposePort(externalPort uint64) error {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if _, ruleExists := p.rules[externalPort]; !ruleExists {
+		return fmt.Errorf(
+			"%w for port %d",
+			ErrPortForwardingRuleNotFound,
+			externalPort,
+		)
+	}
+
+	p.stopManualProxy(externalPort)
+	delete(p.rules, externalPort)
+	delete(p.failedRules, externalPort)
+
+	return p.savePortForwardingRules()
+}
+
+// PortForwardingRules returns the rules ordered by external port.
+func (p *ProxyManager) PortForwardingRules() []entities.PortForwardingRule {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	return p.sortedPortForwardingRules()
+}
+
+func validatePortForwardingRule(rule entities.PortForwardingRule) error {
+	if rule.ExternalPort == 0 || rule.ExternalPort > 65535 {
+		return fmt.Errorf(
+			"%w: external port must be between 1 and 65535",
+			ErrInvalidPortForwardingRule,
+		)
+	}
+
+	if rule.TargetNetwork != "tcp" && rule.TargetNetwork != "unix" {
+		return fmt.Errorf(
+			"%w: target network must be \"tcp\" or \"unix\"",
+			ErrInvalidPortForwardingRule,
+		)
+	}
+
+	if len(rule.TargetAddr) == 0 {
+		return fmt.Errorf(
+			"%w: target address must not be empty",
+			ErrInvalidPortForwardingRule,
+		)
+	}
+
+	return nil
+}
+
+// resolvePortForwardingRuleTarget applies the checks of DialTCP
+// and DialSocket to the target of the rule so that port forwarding
+// could not be used to reach arbitrary hosts or files.
+func (p *ProxyManager) resolvePortForwardingRuleTarget(
+	rule entities.PortForwardingRule,
+) (string, error) {
+
+	if rule.TargetNetwork == "unix" {
+		if !isPathInDirs(rule.TargetAddr, p.config.UnixSocketsDirs) {
+			return "", fmt.Errorf(
+				"%w: target socket must be located in the allowed directories",
+				ErrInvalidPortForwardingRule,
+			)
+		}
+
+		return filepath.Clean(rule.TargetAddr), nil
+	}
+
+	resolvedAddr, err := ResolveContainerLocalAddr(
+		context.Background(),
+		rule.TargetAddr,
+	)
+
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrInvalidPortForwardingRule, err)
+	}
+
+	return resolvedAddr, nil
+}
+
+func (p *ProxyManager) ensureTLSTerminationEnabled(
+	rule entities.PortForwardingRule,
+) error {
+
+	if rule.TLS && p.config.WorkspaceCA == nil {
+		return fmt.Errorf(
+			"%w: %v",
+			ErrInvalidPortForwardingRule,
+			ErrTLSTerminationDisabled,
+		)
+	}
+
+	return nil
+}
+
+// Must be called with the mutex held
+func (p *ProxyManager) startManualProxy(
+	rule entities.PortForwardingRule,
+) error {
+
+	if err := p.ensureTLSTerminationEnabled(rule); err != nil {
+		return err
+	}
+
+	// The persisted rules are checked again
+	// as the allowed targets could have changed
+	targetAddr, err := p.resolvePortForwardingRuleTarget(rule)
+
+	if err != nil {
+		return err
+	}
+
+	bindAddrs, err := p.resolveBindAddrs()
+
+	if err != nil {
+		return err
+	}
+
+	proxy := &localhostProxy{
+		source:            ProxySourceManual,
+		listeningAddrs:    bindAddrs,
+		listeningPort:     rule.ExternalPort,
+		targetNetwork:     rule.TargetNetwork,
+		targetAddr:        targetAddr,
+		tls:               rule.TLS,
+		sendProxyProtocol: rule.SendProxyProtocol,
+		connLimiter:       p.newProxyConnLimiter(rule.ExternalPort),
+		startedAt:         time.Now(),
+		doneChan:          make(chan struct{}),
+	}
+
+	netProxies, err := p.startLocalhostProxy(proxy)
+
+	if err != nil {
+		return err
+	}
+
+	p.manualProxies[rule.ExternalPort] = proxy
+
+	metrics.ActiveProxies.Inc()
+
+	p.publish(ProxyEvent{
+		Type:  ProxyEventTypeStarted,
+		Proxy: proxy.snapshot(),
+	})
+
+	p.handleLocalhostProxyConns(
+		netProxies,
+		proxy,
+	)
+
+	return nil
+}
+
+// Must be called with the mutex held
+func (p *ProxyManager) stopManualProxy(externalPort uint64) {
+	proxy, ok := p.manualProxies[externalPort]
+
+	if !ok {
+		return
+	}
+
+	proxy.close()
+	delete(p.manualProxies, externalPort)
+
+	metrics.ActiveProxies.Dec()
+
+	p.publish(ProxyEvent{
+		Type:  ProxyEventTypeStopped,
+		Proxy: proxy.snapshot(),
+	})
+}
+
+// Must be called with the mutex held
+func (p *ProxyManager) loadPortForwardingRules() error {
+	if len(p.config.RulesFilePath) == 0 {
+		return nil
+	}
+
+	portForwardingRules, err := entities.LoadPortForwardingRules(
+		p.config.RulesFilePath,
+	)
+
+	if err != nil && errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	for _, rule := range portForwardingRules.Rules {
+		p.rules[rule.ExternalPort] = rule
+		p.restartManualProxy(rule)
+	}
+
+	return nil
+}
+
+// failedPortForwardingRule tracks the consecutive
+// failures to start the proxy of a persisted rule.
+type failedPortForwardingRule struct {
+	failures    int
+	nextRetryAt time.Time
+}
+
+// restartManualProxy starts the proxy of a rule that is
+// already persisted. On failure, the start is retried
+// by the reconciliation (see retryFailedManualProxies).
+// Must be called with the mutex held.
+func (p *ProxyManager) restartManualProxy(rule entities.PortForwardingRule) {
+	err := p.startManualProxy(rule)
+
+	if err != nil {
+		p.recordManualProxyStartFailure(rule, err)
+		return
+	}
+
+	if failedRule, ok := p.failedRules[rule.ExternalPort]; ok {
+		logger.Info(
+			"proxy started for port forwarding rule",
+			"port", rule.ExternalPort,
+			"target_addr", rule.TargetAddr,
+			"failures", failedRule.failures,
+		)
+
+		delete(p.failedRules, rule.ExternalPort)
+	}
+}
+
+// Must be called with the mutex held
+func (p *ProxyManager) recordManualProxyStartFailure(
+	rule entities.PortForwardingRule,
+	err error,
+) {
+
+	failedRule, ok := p.failedRules[rule.ExternalPort]
+
+	if !ok {
+		failedRule = &failedPortForwardingRule{}
+		p.failedRules[rule.ExternalPort] = failedRule
+	}
+
+	failedRule.failures++
+	failedRule.nextRetryAt = time.Now().Add(
+		computeProxyStartRetryBackoff(failedRule.failures),
+	)
+
+	// Logged once per series of failures
+	if failedRule.failures > 1 {
+		return
+	}
+
+	logger.Warn(
+		"error when starting proxy for port forwarding rule",
+		"port", rule.ExternalPort,
+		"target_addr", rule.TargetAddr,
+		"error", err,
+		"retry_in", time.Until(failedRule.nextRetryAt).Round(time.Millisecond),
+	)
+
+	p.publish(ProxyEvent{
+		Type: ProxyEventTypeFailed,
+		Proxy: Proxy{
+			Source:            ProxySourceManual,
+			ListeningPort:     rule.ExternalPort,
+			TargetNetwork:     rule.TargetNetwork,
+			TargetAddr:        rule.TargetAddr,
+			TLS:               rule.TLS,
+			SendProxyProtocol: rule.SendProxyProtocol,
+		},
+		Reason: err.Error(),
+	})
+}
+
+// Must be called with the mutex held
+func (p *ProxyManager) retryFailedManualProxies() {
+	for externalPort, failedRule := range p.failedRules {
+		rule, ruleExists := p.rules[externalPort]
+
+		if !ruleExists {
+			delete(p.failedRules, externalPort)
+			continue
+		}
+
+		if time.Now().Before(failedRule.nextRetryAt) {
+			continue
+		}
+
+		p.restartManualProxy(rule)
+	}
+}
+
+// Must be called with the mutex held
+func (p *ProxyManager) savePortForwardingRules() error {
+	if len(p.config.RulesFilePath) == 0 {
+		return nil
+	}
+
+	portForwardingRules := entities.NewPortForwardingRules()
+	portForwardingRules.Rules = p.sortedPortForwardingRules()
+
+	return entities.SavePortForwardingRulesAsFile(
+		p.config.RulesFilePath,
+		portForwardingRules,
+	)
+}
+
+// Must be called with the mutex held
+func (p *ProxyManager) sortedPortForwardingRules() []entities.PortForwardingRule {
+	rules := make([]entities.PortForwardingRule, 0, len(p.rules))
+
+	for _, rule := range p.rules {
+		rules = append(rules, rule)
+	}
+
+	sort.Slice(rules, func(i, j int) bool {
+		return rules[i].ExternalPort < rules[j].ExternalPort
+	})
+
+	return rules
+}
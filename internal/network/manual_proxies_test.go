@@ -0,0 +1,312 @@
+package network
+
+import (
+	"errors"
+	"io"
+	"net"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/yolo-sh/agent-container/entities"
+)
+
+func TestValidatePortForwardingRule(t *testing.T) {
+	testCases := []struct {
+		name          string
+		rule          entities.PortForwardingRule
+		expectedError bool
+	}{
+		{
+			name: "tcp",
+			rule: entities.PortForwardingRule{
+				ExternalPort:  8080,
+				TargetNetwork: "tcp",
+				TargetAddr:    "127.0.0.1:3000",
+			},
+		},
+		{
+			name: "unix",
+			rule: entities.PortForwardingRule{
+				ExternalPort:  8080,
+				TargetNetwork: "unix",
+				TargetAddr:    "/tmp/app.sock",
+			},
+		},
+		{
+			name: "port zero",
+			rule: entities.PortForwardingRule{
+				ExternalPort:  0,
+				TargetNetwork: "tcp",
+				TargetAddr:    "127.0.0.1:3000",
+			},
+			expectedError: true,
+		},
+		{
+			name: "port out of range",
+			rule: entities.PortForwardingRule{
+				ExternalPort:  65536,
+				TargetNetwork: "tcp",
+				TargetAddr:    "127.0.0.1:3000",
+			},
+			expectedError: true,
+		},
+		{
+			name: "unknown network",
+			rule: entities.PortForwardingRule{
+				ExternalPort:  8080,
+				TargetNetwork: "udp",
+				TargetAddr:    "127.0.0.1:3000",
+			},
+			expectedError: true,
+		},
+		{
+			name: "empty address",
+			rule: entities.PortForwardingRule{
+				ExternalPort:  8080,
+				TargetNetwork: "tcp",
+			},
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validatePortForwardingRule(tc.rule)
+
+			if !tc.expectedError {
+				if err != nil {
+					t.Fatalf("unexpected error %v", err)
+				}
+
+				return
+			}
+
+			if !errors.Is(err, ErrInvalidPortForwardingRule) {
+				t.Fatalf("expected %v, got %v", ErrInvalidPortForwardingRule, err)
+			}
+		})
+	}
+}
+
+func TestResolvePortForwardingRuleTarget(t *testing.T) {
+	config := NewDefaultProxyManagerConfig()
+	config.UnixSocketsDirs = []string{"/tmp/sockets"}
+
+	proxyManager := NewProxyManager(config)
+
+	testCases := []struct {
+		name               string
+		rule               entities.PortForwardingRule
+		expectedTargetAddr string
+		expectedError      bool
+	}{
+		{
+			name: "loopback address",
+			rule: entities.PortForwardingRule{
+				TargetNetwork: "tcp",
+				TargetAddr:    "127.0.0.1:3000",
+			},
+			expectedTargetAddr: "127.0.0.1:3000",
+		},
+		{
+			name: "external address",
+			rule: entities.PortForwardingRule{
+				TargetNetwork: "tcp",
+				TargetAddr:    "192.0.2.1:3000",
+			},
+			expectedError: true,
+		},
+		{
+			name: "invalid address",
+			rule: entities.PortForwardingRule{
+				TargetNetwork: "tcp",
+				TargetAddr:    "3000",
+			},
+			expectedError: true,
+		},
+		{
+			name: "socket in allowed dir",
+			rule: entities.PortForwardingRule{
+				TargetNetwork: "unix",
+				TargetAddr:    "/tmp/sockets/app/../app.sock",
+			},
+			expectedTargetAddr: "/tmp/sockets/app.sock",
+		},
+		{
+			name: "socket outside allowed dirs",
+			rule: entities.PortForwardingRule{
+				TargetNetwork: "unix",
+				TargetAddr:    "/var/run/docker.sock",
+			},
+			expectedError: true,
+		},
+		{
+			name: "socket path traversal",
+			rule: entities.PortForwardingRule{
+				TargetNetwork: "unix",
+				TargetAddr:    "/tmp/sockets/../../var/run/docker.sock",
+			},
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			targetAddr, err := proxyManager.resolvePortForwardingRuleTarget(tc.rule)
+
+			if tc.expectedError {
+				if !errors.Is(err, ErrInvalidPortForwardingRule) {
+					t.Fatalf("expected %v, got %v", ErrInvalidPortForwardingRule, err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error %v", err)
+			}
+
+			if targetAddr != tc.expectedTargetAddr {
+				t.Fatalf("expected %q, got %q", tc.expectedTargetAddr, targetAddr)
+			}
+		})
+	}
+}
+
+func TestExposePort(t *testing.T) {
+	rulesFilePath := filepath.Join(t.TempDir(), "port-forwarding-rules.json")
+
+	config := NewDefaultProxyManagerConfig()
+	config.BindAddrs = []string{"127.0.0.1"}
+	config.RulesFilePath = rulesFilePath
+
+	proxyManager := NewProxyManager(config)
+
+	rule := entities.PortForwardingRule{
+		ExternalPort:  getFreeTestPort(t),
+		TargetNetwork: "tcp",
+		TargetAddr:    startTestEchoServer(t),
+	}
+
+	if err := proxyManager.ExposePort(rule); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	proxyAddr := net.JoinHostPort(
+		"127.0.0.1",
+		strconv.FormatUint(rule.ExternalPort, 10),
+	)
+
+	assertTestEcho(t, proxyAddr)
+
+	rules := proxyManager.PortForwardingRules()
+
+	if len(rules) != 1 || rules[0] != rule {
+		t.Fatalf("expected the rule %+v, got %+v", rule, rules)
+	}
+
+	persistedRules, err := entities.LoadPortForwardingRules(rulesFilePath)
+
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	if len(persistedRules.Rules) != 1 || persistedRules.Rules[0] != rule {
+		t.Fatalf("expected the rule %+v persisted, got %+v", rule, persistedRules.Rules)
+	}
+
+	if err := proxyManager.UnexposePort(rule.ExternalPort); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	if _, err := net.Dial("tcp", proxyAddr); err == nil {
+		t.Fatal("expected the proxy to be closed")
+	}
+
+	if rules := proxyManager.PortForwardingRules(); len(rules) != 0 {
+		t.Fatalf("expected no rules, got %+v", rules)
+	}
+
+	err = proxyManager.UnexposePort(rule.ExternalPort)
+
+	if !errors.Is(err, ErrPortForwardingRuleNotFound) {
+		t.Fatalf("expected %v, got %v", ErrPortForwardingRuleNotFound, err)
+	}
+}
+
+// getFreeTestPort returns a port that
+// is not bound on the loopback interface
+func getFreeTestPort(t *testing.T) uint64 {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer listener.Close()
+
+	return uint64(listener.Addr().(*net.TCPAddr).Port)
+}
+
+// startTestEchoServer returns the address of a
+// loopback server that echoes what it reads
+func startTestEchoServer(t *testing.T) string {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() { listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+
+			if err != nil {
+				return
+			}
+
+			go func() {
+				defer conn.Close()
+				io.Copy(conn, conn)
+			}()
+		}
+	}()
+
+	return listener.Addr().String()
+}
+
+func assertTestEcho(t *testing.T, addr string) {
+	t.Helper()
+
+	conn, err := net.DialTimeout("tcp", addr, time.Second)
+
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	defer conn.Close()
+
+	conn.SetDeadline(time.Now().Add(time.Second))
+
+	if _, err := conn.Write([]byte("ping")); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	reply := make([]byte, 4)
+
+	if _, err := io.ReadFull(conn, reply); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	if string(reply) != "ping" {
+		t.Fatalf("expected \"ping\", got %q", reply)
+	}
+}
@@ -0,0 +1,414 @@
+package network
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/yolo-sh/agent-container/constants"
+	"github.com/yolo-sh/agent-container/entities"
+	"github.com/yolo-sh/agent-container/internal/logging"
+	"github.com/yolo-sh/agent-container/internal/metrics"
+)
+
+var logger = logging.Component(logging.ComponentNetwork)
+
+var (
+	ErrProxyManagerAlreadyStarted = errors.New("proxy manager already started")
+	ErrProxyManagerNotStarted     = errors.New("proxy manager not started")
+)
+
+type ProxyManagerConfig struct {
+	// The container addresses (IPv4 and/or IPv6) the proxies
+	// listen on. The loopback listeners are exposed on the
+	// addresses of the same family, when the container has any.
+	// Discovered from the container interfaces when empty.
+	BindAddrs []string
+	// The file the port forwarding rules are persisted to.
+	// The rules are not persisted when empty.
+	RulesFilePath string
+	// The directories the Unix sockets
+	// could be discovered (and dialed) in
+	UnixSocketsDirs []string
+	// The interval between two polls of "/proc/net/tcp"
+	PollInterval time.Duration
+	// The auto-detected services on these ports receive
+	// a PROXY protocol v2 header before the proxied traffic
+	SendProxyProtocolPorts []uint64
+	// Expect a PROXY protocol header (v1 or v2), sent by
+	// an upstream load balancer, on the proxied connections
+	AcceptProxyProtocol bool
+	ConnLimits          ConnLimitsConfig
+	Forwarding          ForwardingConfig
+	// Used to terminate TLS for the port forwarding
+	// rules that request it. Disabled when nil.
+	WorkspaceCA *WorkspaceCA
+}
+
+func NewDefaultProxyManagerConfig() ProxyManagerConfig {
+	return ProxyManagerConfig{
+		BindAddrs:     []string{},
+		RulesFilePath: constants.PortForwardingRulesFilePath,
+		UnixSocketsDirs: []string{
+			"/tmp",
+			"/run",
+			"/var/run",
+			constants.YoloUserHomeDirPath,
+		},
+		PollInterval: 60 * time.Millisecond,
+		Forwarding: ForwardingConfig{
+			LingerTimeout:   30 * time.Second,
+			IdleTimeout:     0,
+			KeepAlivePeriod: 15 * time.Second,
+		},
+		ConnLimits: ConnLimitsConfig{
+			MaxConnsPerPort:    1024,
+			AcceptBurstPerPort: 1,
+		},
+	}
+}
+
+type ProxySource string
+
+const (
+	// Started for a service detected in "/proc/net/tcp"
+	ProxySourceAuto ProxySource = "auto"
+	// Started for a port forwarding rule (see ExposePort)
+	ProxySourceManual ProxySource = "manual"
+)
+
+// Proxy is a snapshot of a running localhost proxy.
+type Proxy struct {
+	Source         ProxySource
+	ListeningAddrs []string
+	ListeningPort  uint64
+	TargetNetwork  string
+	TargetAddr     string
+	// Whether TLS is terminated by the proxy
+	TLS               bool
+	SendProxyProtocol bool
+	StartedAt         time.Time
+}
+
+type ProxyEventType string
+
+const (
+	ProxyEventTypeStarted ProxyEventType = "started"
+	ProxyEventTypeStopped ProxyEventType = "stopped"
+	// The proxy could not be started (see Listeners).
+	// Only sent when the failure reason changes.
+	ProxyEventTypeFailed ProxyEventType = "failed"
+)
+
+type ProxyEvent struct {
+	Type  ProxyEventType
+	Proxy Proxy
+	// Set for the "failed" events
+	Reason string
+}
+
+// Events are dropped for the subscribers
+// that don't keep up with this buffer.
+const proxyEventsBufferSize = 64
+
+// ProxyManager polls "/proc/net/tcp" for services
+// listening on the loopback interface and starts
+// (or stops) the proxies that expose them on "BindAddrs".
+// All methods are safe for concurrent use.
+// The logger and the Prometheus metrics are
+// package-level: they are shared by all the managers.
+type ProxyManager struct {
+	config   ProxyManagerConfig
+	stats    *proxiesStatsRegistry
+	totalFDs *fdsBudget
+
+	mutex   sync.Mutex
+	proxies map[localhostListenerID]*localhostProxy
+	// The loopback and wildcard listeners
+	// found during the last reconciliation
+	listeners map[localhostListenerID]*detectedListener
+	// Keyed by external port
+	manualProxies map[uint64]*localhostProxy
+	rules         map[uint64]entities.PortForwardingRule
+	// The rules whose proxy could not be started,
+	// retried during the reconciliation
+	failedRules      map[uint64]*failedPortForwardingRule
+	excludedPorts    map[uint64]int
+	subscribers      map[uint64]chan ProxyEvent
+	nextSubscriberID uint64
+
+	started  bool
+	stopChan chan struct{}
+	doneChan chan struct{}
+	err      error
+}
+
+func NewProxyManager(config ProxyManagerConfig) *ProxyManager {
+	return &ProxyManager{
+		config:        config,
+		stats:         newProxiesStatsRegistry(),
+		totalFDs:      newFDsBudget(config.ConnLimits.MaxFDsTotal),
+		proxies:       map[localhostListenerID]*localhostProxy{},
+		listeners:     map[localhostListenerID]*detectedListener{},
+		manualProxies: map[uint64]*localhostProxy{},
+		rules:         map[uint64]entities.PortForwardingRule{},
+		failedRules:   map[uint64]*failedPortForwardingRule{},
+		excludedPorts: map[uint64]int{},
+		subscribers:   map[uint64]chan ProxyEvent{},
+		stopChan:      make(chan struct{}),
+		doneChan:      make(chan struct{}),
+	}
+}
+
+// Start starts the proxies for the persisted
+// port forwarding rules and polls in the background.
+// Use Wait to be notified of the polling errors.
+func (p *ProxyManager) Start() error {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if p.started {
+		return ErrProxyManagerAlreadyStarted
+	}
+
+	err := p.loadPortForwardingRules()
+
+	if err != nil {
+		return err
+	}
+
+	p.started = true
+
+	go p.poll()
+
+	return nil
+}
+
+// Stop stops polling, closes all the proxies
+// and the subscribers channels.
+// The connections already accepted are left open.
+func (p *ProxyManager) Stop() error {
+	p.mutex.Lock()
+
+	if !p.started {
+		p.mutex.Unlock()
+		return ErrProxyManagerNotStarted
+	}
+
+	select {
+	case <-p.stopChan: // Already stopped
+	default:
+		close(p.stopChan)
+	}
+
+	p.mutex.Unlock()
+
+	<-p.doneChan
+
+	return nil
+}
+
+// Wait blocks until the manager is stopped
+// and returns the error that stopped it, if any.
+func (p *ProxyManager) Wait() error {
+	<-p.doneChan
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	return p.err
+}
+
+func (p *ProxyManager) poll() {
+	var pollErr error
+
+	for {
+		pollErr = p.ReconcileLocalhostProxiesState()
+
+		if pollErr != nil {
+			break
+		}
+
+		select {
+		case <-p.stopChan:
+		case <-time.After(p.config.PollInterval):
+			continue
+		}
+
+		break
+	}
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.err = pollErr
+
+	for listenerID := range p.proxies {
+		p.stopProxy(listenerID)
+	}
+
+	for externalPort := range p.manualProxies {
+		p.stopManualProxy(externalPort)
+	}
+
+	for subscriberID, subscriberChan := range p.subscribers {
+		close(subscriberChan)
+		delete(p.subscribers, subscriberID)
+	}
+
+	close(p.doneChan)
+}
+
+// List returns the running proxies.
+func (p *ProxyManager) List() []Proxy {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	proxies := make([]Proxy, 0, len(p.proxies))
+
+	for _, proxy := range p.proxies {
+		proxies = append(proxies, proxy.snapshot())
+	}
+
+	for _, proxy := range p.manualProxies {
+		proxies = append(proxies, proxy.snapshot())
+	}
+
+	return proxies
+}
+
+// ExcludePort prevents the loopback listeners on "port"
+// from being proxied (eg: the listeners of the reverse
+// tunnels, that must not be exposed back to the host).
+// Call the returned function to lift the exclusion.
+func (p *ProxyManager) ExcludePort(port uint64) func() {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.excludedPorts[port]++
+
+	for listenerID, proxy := range p.proxies {
+		if proxy.listeningPort == port {
+			p.stopProxy(listenerID)
+		}
+	}
+
+	var once sync.Once
+
+	return func() {
+		once.Do(func() {
+			p.mutex.Lock()
+			defer p.mutex.Unlock()
+
+			p.excludedPorts[port]--
+
+			if p.excludedPorts[port] <= 0 {
+				delete(p.excludedPorts, port)
+			}
+		})
+	}
+}
+
+// Stats returns the connections stats
+// aggregated per forwarded port, ordered by port.
+func (p *ProxyManager) Stats() []ProxyPortStats {
+	return p.stats.list()
+}
+
+// Subscribe returns a channel that receives
+// an event each time a proxy is started or stopped
+// and a function to unsubscribe.
+func (p *ProxyManager) Subscribe() (<-chan ProxyEvent, func()) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	subscriberID := p.nextSubscriberID
+	p.nextSubscriberID++
+
+	subscriberChan := make(chan ProxyEvent, proxyEventsBufferSize)
+
+	select {
+	case <-p.doneChan:
+		close(subscriberChan)
+		return subscriberChan, func() {}
+	default:
+	}
+
+	p.subscribers[subscriberID] = subscriberChan
+
+	unsubscribe := func() {
+		p.mutex.Lock()
+		defer p.mutex.Unlock()
+
+		if _, ok := p.subscribers[subscriberID]; !ok {
+			return
+		}
+
+		close(subscriberChan)
+		delete(p.subscribers, subscriberID)
+	}
+
+	return subscriberChan, unsubscribe
+}
+
+// Must be called with the mutex held
+func (p *ProxyManager) publish(event ProxyEvent) {
+	for _, subscriberChan := range p.subscribers {
+		select {
+		case subscriberChan <- event:
+		default:
+			logger.Warn(
+				"proxy event dropped for slow subscriber",
+				"type", event.Type,
+				"port", event.Proxy.ListeningPort,
+			)
+		}
+	}
+}
+
+// Must be called with the mutex held
+func (p *ProxyManager) stopProxy(listenerID localhostListenerID) {
+	proxy, ok := p.proxies[listenerID]
+
+	if !ok {
+		return
+	}
+
+	proxy.close()
+	delete(p.proxies, listenerID)
+
+	metrics.ActiveProxies.Dec()
+
+	p.publish(ProxyEvent{
+		Type:  ProxyEventTypeStopped,
+		Proxy: proxy.snapshot(),
+	})
+}
+
+// WorkspaceCA returns the CA used to terminate TLS
+// or ErrTLSTerminationDisabled when not configured.
+func (p *ProxyManager) WorkspaceCA() (*WorkspaceCA, error) {
+	if p.config.WorkspaceCA == nil {
+		return nil, ErrTLSTerminationDisabled
+	}
+
+	return p.config.WorkspaceCA, nil
+}
+
+func (p *ProxyManager) isSendProxyProtocolPort(port uint64) bool {
+	for _, sendProxyProtocolPort := range p.config.SendProxyProtocolPorts {
+		if sendProxyProtocolPort == port {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (p *ProxyManager) newProxyConnLimiter(port uint64) *proxyConnLimiter {
+	return newProxyConnLimiter(
+		port,
+		p.config.ConnLimits,
+		p.totalFDs,
+	)
+}
@@ -0,0 +1,302 @@
+package network
+
+import (
+	"errors"
+	"net"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/yolo-sh/agent-container/entities"
+)
+
+func TestProxyManagerStartStop(t *testing.T) {
+	rulesFilePath := filepath.Join(t.TempDir(), "port-forwarding-rules.json")
+
+	rule := entities.PortForwardingRule{
+		ExternalPort:  getFreeTestPort(t),
+		TargetNetwork: "tcp",
+		TargetAddr:    startTestEchoServer(t),
+	}
+
+	portForwardingRules := entities.NewPortForwardingRules()
+	portForwardingRules.Rules = []entities.PortForwardingRule{rule}
+
+	err := entities.SavePortForwardingRulesAsFile(rulesFilePath, portForwardingRules)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	config := NewDefaultProxyManagerConfig()
+	config.BindAddrs = []string{"127.0.0.2"}
+	config.RulesFilePath = rulesFilePath
+
+	proxyManager := NewProxyManager(config)
+
+	if err := proxyManager.Stop(); !errors.Is(err, ErrProxyManagerNotStarted) {
+		t.Fatalf("expected %v, got %v", ErrProxyManagerNotStarted, err)
+	}
+
+	if err := proxyManager.Start(); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	if err := proxyManager.Start(); !errors.Is(err, ErrProxyManagerAlreadyStarted) {
+		t.Fatalf("expected %v, got %v", ErrProxyManagerAlreadyStarted, err)
+	}
+
+	events, _ := proxyManager.Subscribe()
+
+	// The persisted rules are restored
+	proxyAddr := net.JoinHostPort(
+		"127.0.0.2",
+		strconv.FormatUint(rule.ExternalPort, 10),
+	)
+
+	assertTestEcho(t, proxyAddr)
+
+	if proxy, ok := findTestProxy(proxyManager.List(), rule.ExternalPort); !ok ||
+		proxy.Source != ProxySourceManual {
+
+		t.Fatalf("expected a manual proxy on port %d, got %+v", rule.ExternalPort, proxyManager.List())
+	}
+
+	if err := proxyManager.Stop(); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	if err := proxyManager.Wait(); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	if proxies := proxyManager.List(); len(proxies) != 0 {
+		t.Fatalf("expected no proxies, got %+v", proxies)
+	}
+
+	if _, err := net.Dial("tcp", proxyAddr); err == nil {
+		t.Fatal("expected the proxy to be closed")
+	}
+
+	// The subscribers channels are closed
+	assertTestEventsClosed(t, events)
+
+	closedEvents, _ := proxyManager.Subscribe()
+	assertTestEventsClosed(t, closedEvents)
+
+	// Stopping twice is a no-op
+	if err := proxyManager.Stop(); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+}
+
+func TestProxyManagerSubscribe(t *testing.T) {
+	proxyManager := newTestProxyManager()
+	listeners, listenersByPort, port := newTestLoopbackListeners(t)
+
+	events, unsubscribe := proxyManager.Subscribe()
+
+	proxyManager.reconcileLocalhostProxiesState(listeners, listenersByPort)
+
+	event := receiveTestEvent(t, events)
+
+	if event.Type != ProxyEventTypeStarted || event.Proxy.ListeningPort != port {
+		t.Fatalf("expected a started event for port %d, got %+v", port, event)
+	}
+
+	if proxy, ok := findTestProxy(proxyManager.List(), port); !ok ||
+		proxy.Source != ProxySourceAuto {
+
+		t.Fatalf("expected an auto proxy on port %d, got %+v", port, proxyManager.List())
+	}
+
+	proxyManager.reconcileLocalhostProxiesState(localhostListeners{}, portListeners{})
+
+	event = receiveTestEvent(t, events)
+
+	if event.Type != ProxyEventTypeStopped || event.Proxy.ListeningPort != port {
+		t.Fatalf("expected a stopped event for port %d, got %+v", port, event)
+	}
+
+	if proxies := proxyManager.List(); len(proxies) != 0 {
+		t.Fatalf("expected no proxies, got %+v", proxies)
+	}
+
+	unsubscribe()
+	assertTestEventsClosed(t, events)
+
+	// Unsubscribing twice is a no-op
+	unsubscribe()
+}
+
+func TestProxyManagerPublishDropsEventsForSlowSubscribers(t *testing.T) {
+	proxyManager := newTestProxyManager()
+
+	slowEvents, unsubscribe := proxyManager.Subscribe()
+	defer unsubscribe()
+
+	proxyManager.mutex.Lock()
+
+	for i := 0; i < proxyEventsBufferSize+1; i++ {
+		proxyManager.publish(ProxyEvent{
+			Type:  ProxyEventTypeStarted,
+			Proxy: Proxy{ListeningPort: uint64(i)},
+		})
+	}
+
+	proxyManager.mutex.Unlock()
+
+	if len(slowEvents) != proxyEventsBufferSize {
+		t.Fatalf("expected %d buffered events, got %d", proxyEventsBufferSize, len(slowEvents))
+	}
+
+	// The events that don't fit in the buffer are dropped
+	for i := 0; i < proxyEventsBufferSize; i++ {
+		if event := <-slowEvents; event.Proxy.ListeningPort != uint64(i) {
+			t.Fatalf("expected the event for port %d, got %+v", i, event)
+		}
+	}
+}
+
+func TestProxyManagerExcludePort(t *testing.T) {
+	proxyManager := newTestProxyManager()
+	listeners, listenersByPort, port := newTestLoopbackListeners(t)
+
+	// Stops the proxies
+	defer proxyManager.reconcileLocalhostProxiesState(
+		localhostListeners{},
+		portListeners{},
+	)
+
+	proxyManager.reconcileLocalhostProxiesState(listeners, listenersByPort)
+
+	if _, ok := findTestProxy(proxyManager.List(), port); !ok {
+		t.Fatalf("expected a proxy on port %d", port)
+	}
+
+	liftExclusion := proxyManager.ExcludePort(port)
+	liftOtherExclusion := proxyManager.ExcludePort(port)
+
+	// The running proxy is stopped
+	if _, ok := findTestProxy(proxyManager.List(), port); ok {
+		t.Fatalf("expected no proxy on port %d", port)
+	}
+
+	proxyManager.reconcileLocalhostProxiesState(listeners, listenersByPort)
+
+	assertTestListenerState(t, proxyManager, ListenerStateSkipped)
+
+	liftExclusion()
+	// Lifted once
+	liftExclusion()
+
+	proxyManager.reconcileLocalhostProxiesState(listeners, listenersByPort)
+
+	assertTestListenerState(t, proxyManager, ListenerStateSkipped)
+
+	liftOtherExclusion()
+
+	proxyManager.reconcileLocalhostProxiesState(listeners, listenersByPort)
+
+	assertTestListenerState(t, proxyManager, ListenerStateProxied)
+}
+
+// newTestProxyManager returns a manager that binds its
+// proxies on "127.0.0.2" so that they don't conflict with
+// the (fake) listeners on "127.0.0.1" they are started for
+func newTestProxyManager() *ProxyManager {
+	config := NewDefaultProxyManagerConfig()
+	config.BindAddrs = []string{"127.0.0.2"}
+	config.RulesFilePath = ""
+
+	return NewProxyManager(config)
+}
+
+// newTestLoopbackListeners returns the listeners
+// passed to "reconcileLocalhostProxiesState" for
+// an echo server listening on "127.0.0.1"
+func newTestLoopbackListeners(
+	t *testing.T,
+) (localhostListeners, portListeners, uint64) {
+
+	t.Helper()
+
+	echoServerAddr := startTestEchoServer(t)
+
+	_, portAsString, err := net.SplitHostPort(echoServerAddr)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	port, err := strconv.ParseUint(portAsString, 10, 64)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	listenerID := localhostListenerID(echoServerAddr)
+
+	listeners := localhostListeners{
+		listenerID: {
+			listeningAddr: "127.0.0.1",
+			listeningPort: port,
+		},
+	}
+
+	return listeners, portListeners{port: {listenerID}}, port
+}
+
+func findTestProxy(proxies []Proxy, port uint64) (Proxy, bool) {
+	for _, proxy := range proxies {
+		if proxy.ListeningPort == port {
+			return proxy, true
+		}
+	}
+
+	return Proxy{}, false
+}
+
+func receiveTestEvent(t *testing.T, events <-chan ProxyEvent) ProxyEvent {
+	t.Helper()
+
+	select {
+	case event := <-events:
+		return event
+	case <-time.After(time.Second):
+		t.Fatal("expected an event")
+	}
+
+	return ProxyEvent{}
+}
+
+func assertTestEventsClosed(t *testing.T, events <-chan ProxyEvent) {
+	t.Helper()
+
+	for {
+		select {
+		case _, ok := <-events:
+			if !ok {
+				return
+			}
+		case <-time.After(time.Second):
+			t.Fatal("expected the events channel to be closed")
+		}
+	}
+}
+
+func assertTestListenerState(
+	t *testing.T,
+	proxyManager *ProxyManager,
+	expectedState ListenerState,
+) {
+
+	t.Helper()
+
+	listeners := proxyManager.Listeners()
+
+	if len(listeners) != 1 || listeners[0].State != expectedState {
+		t.Fatalf("expected one listener in state %q, got %+v", expectedState, listeners)
+	}
+}
@@ -0,0 +1,372 @@
+package network
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// See: https://www.haproxy.org/download/2.6/doc/proxy-protocol.txt
+
+var ErrInvalidProxyProtocolHeader = errors.New("invalid PROXY protocol header")
+
+var (
+	proxyProtocolV1Prefix    = []byte("PROXY ")
+	proxyProtocolV2Signature = []byte("\r\n\r\n\x00\r\nQUIT\n")
+)
+
+const (
+	proxyProtocolV2CmdLocal = 0x20
+	proxyProtocolV2CmdProxy = 0x21
+
+	proxyProtocolV2FamTCP4 = 0x11
+	proxyProtocolV2FamTCP6 = 0x21
+
+	// Including the CRLF
+	proxyProtocolV1MaxHeaderLen = 107
+
+	// How long the upstream has to send the header
+	proxyProtocolHeaderTimeout = 5 * time.Second
+)
+
+// buildProxyProtocolV2Header returns the header sent to the
+// services before the proxied traffic so that they could see
+// the real client address. A "LOCAL" header is returned when
+// the addresses are not TCP ones.
+func buildProxyProtocolV2Header(srcAddr, dstAddr net.Addr) []byte {
+	header := bytes.NewBuffer(nil)
+	header.Write(proxyProtocolV2Signature)
+
+	srcTCPAddr, srcIsTCP := srcAddr.(*net.TCPAddr)
+	dstTCPAddr, dstIsTCP := dstAddr.(*net.TCPAddr)
+
+	if !srcIsTCP || !dstIsTCP {
+		header.Write([]byte{proxyProtocolV2CmdLocal, 0x00, 0x00, 0x00})
+		return header.Bytes()
+	}
+
+	srcIPv4 := srcTCPAddr.IP.To4()
+	dstIPv4 := dstTCPAddr.IP.To4()
+
+	var addrs []byte
+
+	if srcIPv4 != nil && dstIPv4 != nil {
+		header.Write([]byte{proxyProtocolV2CmdProxy, proxyProtocolV2FamTCP4})
+
+		addrs = append(addrs, srcIPv4...)
+		addrs = append(addrs, dstIPv4...)
+	} else {
+		// Mixed families are sent as IPv4-mapped IPv6 addresses
+		header.Write([]byte{proxyProtocolV2CmdProxy, proxyProtocolV2FamTCP6})
+
+		addrs = append(addrs, srcTCPAddr.IP.To16()...)
+		addrs = append(addrs, dstTCPAddr.IP.To16()...)
+	}
+
+	ports := make([]byte, 4)
+	binary.BigEndian.PutUint16(ports[0:], uint16(srcTCPAddr.Port))
+	binary.BigEndian.PutUint16(ports[2:], uint16(dstTCPAddr.Port))
+
+	addrs = append(addrs, ports...)
+
+	addrsLen := make([]byte, 2)
+	binary.BigEndian.PutUint16(addrsLen, uint16(len(addrs)))
+
+	header.Write(addrsLen)
+	header.Write(addrs)
+
+	return header.Bytes()
+}
+
+// proxyProtocolListener expects a PROXY protocol header
+// (v1 or v2) at the start of each accepted connection,
+// sent by an upstream load balancer.
+type proxyProtocolListener struct {
+	net.Listener
+}
+
+func newProxyProtocolListener(listener net.Listener) net.Listener {
+	return &proxyProtocolListener{
+		Listener: listener,
+	}
+}
+
+func (l *proxyProtocolListener) Accept() (net.Conn, error) {
+	conn, err := l.Listener.Accept()
+
+	if err != nil {
+		return nil, err
+	}
+
+	return newProxyProtocolConn(conn), nil
+}
+
+// proxyProtocolConn reads the PROXY protocol header lazily,
+// on the first call to Read, RemoteAddr or LocalAddr, so that
+// a slow upstream doesn't block the accept loop.
+// The addresses from the header replace the connection ones.
+type proxyProtocolConn struct {
+	net.Conn
+	reader *bufio.Reader
+
+	headerOnce sync.Once
+	headerErr  error
+	remoteAddr net.Addr
+	localAddr  net.Addr
+
+	// The read deadline set by the caller, restored
+	// once the header has been read (see readHeaderOnce)
+	readDeadlineMutex sync.Mutex
+	readDeadline      time.Time
+}
+
+func newProxyProtocolConn(conn net.Conn) *proxyProtocolConn {
+	return &proxyProtocolConn{
+		Conn:   conn,
+		reader: bufio.NewReader(conn),
+	}
+}
+
+func (c *proxyProtocolConn) Read(b []byte) (int, error) {
+	c.readHeaderOnce()
+
+	if c.headerErr != nil {
+		return 0, c.headerErr
+	}
+
+	return c.reader.Read(b)
+}
+
+func (c *proxyProtocolConn) RemoteAddr() net.Addr {
+	c.readHeaderOnce()
+
+	if c.remoteAddr != nil {
+		return c.remoteAddr
+	}
+
+	return c.Conn.RemoteAddr()
+}
+
+func (c *proxyProtocolConn) LocalAddr() net.Addr {
+	c.readHeaderOnce()
+
+	if c.localAddr != nil {
+		return c.localAddr
+	}
+
+	return c.Conn.LocalAddr()
+}
+
+// CloseWrite lets the half-closes be propagated
+func (c *proxyProtocolConn) CloseWrite() error {
+	return closeConnWrite(c.Conn)
+}
+
+func (c *proxyProtocolConn) SetDeadline(t time.Time) error {
+	c.readDeadlineMutex.Lock()
+	defer c.readDeadlineMutex.Unlock()
+
+	c.readDeadline = t
+
+	return c.Conn.SetDeadline(t)
+}
+
+func (c *proxyProtocolConn) SetReadDeadline(t time.Time) error {
+	c.readDeadlineMutex.Lock()
+	defer c.readDeadlineMutex.Unlock()
+
+	c.readDeadline = t
+
+	return c.Conn.SetReadDeadline(t)
+}
+
+// readHeaderOnce bounds the header read with its own timeout
+// (or the caller deadline, if sooner) then restores the
+// caller deadline so that it is not cleared by the header read.
+func (c *proxyProtocolConn) readHeaderOnce() {
+	c.headerOnce.Do(func() {
+		c.readDeadlineMutex.Lock()
+		defer c.readDeadlineMutex.Unlock()
+
+		headerDeadline := time.Now().Add(proxyProtocolHeaderTimeout)
+
+		if !c.readDeadline.IsZero() && c.readDeadline.Before(headerDeadline) {
+			headerDeadline = c.readDeadline
+		}
+
+		c.Conn.SetReadDeadline(headerDeadline)
+		defer c.Conn.SetReadDeadline(c.readDeadline)
+
+		c.remoteAddr, c.localAddr, c.headerErr = readProxyProtocolHeader(c.reader)
+	})
+}
+
+// readProxyProtocolHeader returns nil addresses for
+// the "LOCAL" (v2) and "UNKNOWN" (v1) headers.
+func readProxyProtocolHeader(
+	reader *bufio.Reader,
+) (net.Addr, net.Addr, error) {
+
+	prefix, err := reader.Peek(len(proxyProtocolV1Prefix))
+
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %v", ErrInvalidProxyProtocolHeader, err)
+	}
+
+	if bytes.Equal(prefix, proxyProtocolV1Prefix) {
+		return readProxyProtocolV1Header(reader)
+	}
+
+	return readProxyProtocolV2Header(reader)
+}
+
+func readProxyProtocolV1Header(
+	reader *bufio.Reader,
+) (net.Addr, net.Addr, error) {
+
+	line := []byte{}
+
+	for !bytes.HasSuffix(line, []byte("\r\n")) {
+		if len(line) >= proxyProtocolV1MaxHeaderLen {
+			return nil, nil, fmt.Errorf(
+				"%w: v1 header too long",
+				ErrInvalidProxyProtocolHeader,
+			)
+		}
+
+		b, err := reader.ReadByte()
+
+		if err != nil {
+			return nil, nil, fmt.Errorf("%w: %v", ErrInvalidProxyProtocolHeader, err)
+		}
+
+		line = append(line, b)
+	}
+
+	// "PROXY TCP4 <src ip> <dst ip> <src port> <dst port>"
+	fields := strings.Fields(strings.TrimSuffix(string(line), "\r\n"))
+
+	if len(fields) >= 2 && fields[1] == "UNKNOWN" {
+		return nil, nil, nil
+	}
+
+	if len(fields) != 6 || (fields[1] != "TCP4" && fields[1] != "TCP6") {
+		return nil, nil, fmt.Errorf(
+			"%w: malformed v1 header",
+			ErrInvalidProxyProtocolHeader,
+		)
+	}
+
+	srcAddr, err := parseProxyProtocolV1Addr(fields[2], fields[4])
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	dstAddr, err := parseProxyProtocolV1Addr(fields[3], fields[5])
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return srcAddr, dstAddr, nil
+}
+
+func parseProxyProtocolV1Addr(ip, port string) (*net.TCPAddr, error) {
+	parsedIP := net.ParseIP(ip)
+	parsedPort, err := strconv.ParseUint(port, 10, 16)
+
+	if parsedIP == nil || err != nil {
+		return nil, fmt.Errorf(
+			"%w: invalid v1 address %s:%s",
+			ErrInvalidProxyProtocolHeader,
+			ip,
+			port,
+		)
+	}
+
+	return &net.TCPAddr{
+		IP:   parsedIP,
+		Port: int(parsedPort),
+	}, nil
+}
+
+func readProxyProtocolV2Header(
+	reader *bufio.Reader,
+) (net.Addr, net.Addr, error) {
+
+	// Signature, version / command, family / protocol and length
+	header := make([]byte, len(proxyProtocolV2Signature)+4)
+
+	if _, err := io.ReadFull(reader, header); err != nil {
+		return nil, nil, fmt.Errorf("%w: %v", ErrInvalidProxyProtocolHeader, err)
+	}
+
+	if !bytes.Equal(header[:len(proxyProtocolV2Signature)], proxyProtocolV2Signature) {
+		return nil, nil, fmt.Errorf(
+			"%w: missing header",
+			ErrInvalidProxyProtocolHeader,
+		)
+	}
+
+	versionAndCommand := header[12]
+	family := header[13]
+	addrsLen := binary.BigEndian.Uint16(header[14:16])
+
+	addrs := make([]byte, addrsLen)
+
+	if _, err := io.ReadFull(reader, addrs); err != nil {
+		return nil, nil, fmt.Errorf("%w: %v", ErrInvalidProxyProtocolHeader, err)
+	}
+
+	if versionAndCommand == proxyProtocolV2CmdLocal {
+		return nil, nil, nil
+	}
+
+	if versionAndCommand != proxyProtocolV2CmdProxy {
+		return nil, nil, fmt.Errorf(
+			"%w: unsupported v2 version or command 0x%x",
+			ErrInvalidProxyProtocolHeader,
+			versionAndCommand,
+		)
+	}
+
+	ipLen := 0
+
+	switch family {
+	case proxyProtocolV2FamTCP4:
+		ipLen = net.IPv4len
+	case proxyProtocolV2FamTCP6:
+		ipLen = net.IPv6len
+	default: // UDP, Unix sockets...
+		return nil, nil, nil
+	}
+
+	if len(addrs) < ipLen*2+4 {
+		return nil, nil, fmt.Errorf(
+			"%w: v2 addresses too short",
+			ErrInvalidProxyProtocolHeader,
+		)
+	}
+
+	srcAddr := &net.TCPAddr{
+		IP:   net.IP(addrs[:ipLen]),
+		Port: int(binary.BigEndian.Uint16(addrs[ipLen*2:])),
+	}
+
+	dstAddr := &net.TCPAddr{
+		IP:   net.IP(addrs[ipLen : ipLen*2]),
+		Port: int(binary.BigEndian.Uint16(addrs[ipLen*2+2:])),
+	}
+
+	// The remaining bytes are TLVs, ignored
+	return srcAddr, dstAddr, nil
+}
@@ -0,0 +1,227 @@
+package network
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"net"
+	"testing"
+	"time"
+)
+
+func TestReadProxyProtocolHeader(t *testing.T) {
+	testCases := []struct {
+		name            string
+		header          string
+		expectedSrcAddr string
+		expectedDstAddr string
+		expectedError   error
+	}{
+		{
+			name:            "v1 TCP4",
+			header:          "PROXY TCP4 192.0.2.1 192.0.2.2 56324 443\r\n",
+			expectedSrcAddr: "192.0.2.1:56324",
+			expectedDstAddr: "192.0.2.2:443",
+		},
+		{
+			name:            "v1 TCP6",
+			header:          "PROXY TCP6 2001:db8::1 2001:db8::2 56324 443\r\n",
+			expectedSrcAddr: "[2001:db8::1]:56324",
+			expectedDstAddr: "[2001:db8::2]:443",
+		},
+		{
+			name:   "v1 UNKNOWN",
+			header: "PROXY UNKNOWN\r\n",
+		},
+		{
+			name:          "v1 invalid IP",
+			header:        "PROXY TCP4 192.0.2 192.0.2.2 56324 443\r\n",
+			expectedError: ErrInvalidProxyProtocolHeader,
+		},
+		{
+			name:          "v1 invalid port",
+			header:        "PROXY TCP4 192.0.2.1 192.0.2.2 65536 443\r\n",
+			expectedError: ErrInvalidProxyProtocolHeader,
+		},
+		{
+			name:          "v1 missing fields",
+			header:        "PROXY TCP4 192.0.2.1 192.0.2.2 56324\r\n",
+			expectedError: ErrInvalidProxyProtocolHeader,
+		},
+		{
+			name:          "v1 too long",
+			header:        "PROXY TCP4 " + string(bytes.Repeat([]byte("1"), proxyProtocolV1MaxHeaderLen)) + "\r\n",
+			expectedError: ErrInvalidProxyProtocolHeader,
+		},
+		{
+			name:          "v1 truncated",
+			header:        "PROXY TCP4 192.0.2.1",
+			expectedError: ErrInvalidProxyProtocolHeader,
+		},
+		{
+			name: "v2 TCP4",
+			header: string(buildProxyProtocolV2Header(
+				&net.TCPAddr{IP: net.ParseIP("192.0.2.1"), Port: 56324},
+				&net.TCPAddr{IP: net.ParseIP("192.0.2.2"), Port: 443},
+			)),
+			expectedSrcAddr: "192.0.2.1:56324",
+			expectedDstAddr: "192.0.2.2:443",
+		},
+		{
+			name: "v2 TCP6",
+			header: string(buildProxyProtocolV2Header(
+				&net.TCPAddr{IP: net.ParseIP("2001:db8::1"), Port: 56324},
+				&net.TCPAddr{IP: net.ParseIP("2001:db8::2"), Port: 443},
+			)),
+			expectedSrcAddr: "[2001:db8::1]:56324",
+			expectedDstAddr: "[2001:db8::2]:443",
+		},
+		{
+			name:   "v2 LOCAL",
+			header: string(proxyProtocolV2Signature) + "\x20\x00\x00\x00",
+		},
+		{
+			name:   "v2 UDP is ignored",
+			header: string(proxyProtocolV2Signature) + "\x21\x12\x00\x0c" + string(make([]byte, 12)),
+		},
+		{
+			name:          "v2 unsupported version",
+			header:        string(proxyProtocolV2Signature) + "\x31\x11\x00\x0c" + string(make([]byte, 12)),
+			expectedError: ErrInvalidProxyProtocolHeader,
+		},
+		{
+			name:          "v2 addresses too short",
+			header:        string(proxyProtocolV2Signature) + "\x21\x11\x00\x04" + string(make([]byte, 4)),
+			expectedError: ErrInvalidProxyProtocolHeader,
+		},
+		{
+			name:          "v2 truncated addresses",
+			header:        string(proxyProtocolV2Signature) + "\x21\x11\x00\x0c\x00",
+			expectedError: ErrInvalidProxyProtocolHeader,
+		},
+		{
+			name:          "no header",
+			header:        "GET / HTTP/1.1\r\nHost: localhost\r\n\r\n",
+			expectedError: ErrInvalidProxyProtocolHeader,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			srcAddr, dstAddr, err := readProxyProtocolHeader(
+				bufio.NewReader(bytes.NewBufferString(tc.header)),
+			)
+
+			if !errors.Is(err, tc.expectedError) {
+				t.Fatalf("expected error %v, got %v", tc.expectedError, err)
+			}
+
+			if addrString(srcAddr) != tc.expectedSrcAddr {
+				t.Fatalf("expected source %q, got %q", tc.expectedSrcAddr, addrString(srcAddr))
+			}
+
+			if addrString(dstAddr) != tc.expectedDstAddr {
+				t.Fatalf("expected destination %q, got %q", tc.expectedDstAddr, addrString(dstAddr))
+			}
+		})
+	}
+}
+
+func TestBuildProxyProtocolV2Header(t *testing.T) {
+	testCases := []struct {
+		name           string
+		srcAddr        net.Addr
+		dstAddr        net.Addr
+		expectedFamily byte
+		expectedLen    int
+	}{
+		{
+			name:           "IPv4",
+			srcAddr:        &net.TCPAddr{IP: net.ParseIP("192.0.2.1"), Port: 1},
+			dstAddr:        &net.TCPAddr{IP: net.ParseIP("192.0.2.2"), Port: 2},
+			expectedFamily: proxyProtocolV2FamTCP4,
+			expectedLen:    12,
+		},
+		{
+			name:           "IPv6",
+			srcAddr:        &net.TCPAddr{IP: net.ParseIP("2001:db8::1"), Port: 1},
+			dstAddr:        &net.TCPAddr{IP: net.ParseIP("2001:db8::2"), Port: 2},
+			expectedFamily: proxyProtocolV2FamTCP6,
+			expectedLen:    36,
+		},
+		{
+			name:           "mixed families",
+			srcAddr:        &net.TCPAddr{IP: net.ParseIP("192.0.2.1"), Port: 1},
+			dstAddr:        &net.TCPAddr{IP: net.ParseIP("2001:db8::2"), Port: 2},
+			expectedFamily: proxyProtocolV2FamTCP6,
+			expectedLen:    36,
+		},
+		{
+			name:        "not TCP",
+			srcAddr:     &net.UnixAddr{Name: "/tmp/a.sock", Net: "unix"},
+			dstAddr:     &net.UnixAddr{Name: "/tmp/b.sock", Net: "unix"},
+			expectedLen: 0,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			header := buildProxyProtocolV2Header(tc.srcAddr, tc.dstAddr)
+			signatureLen := len(proxyProtocolV2Signature)
+
+			if !bytes.HasPrefix(header, proxyProtocolV2Signature) {
+				t.Fatalf("missing signature in %x", header)
+			}
+
+			if len(header) != signatureLen+4+tc.expectedLen {
+				t.Fatalf("expected %d address bytes, got header %x", tc.expectedLen, header)
+			}
+
+			if tc.expectedLen == 0 {
+				if header[signatureLen] != proxyProtocolV2CmdLocal {
+					t.Fatalf("expected a LOCAL header, got %x", header)
+				}
+
+				return
+			}
+
+			if header[signatureLen+1] != tc.expectedFamily {
+				t.Fatalf("expected family 0x%x, got 0x%x", tc.expectedFamily, header[signatureLen+1])
+			}
+		})
+	}
+}
+
+func TestProxyProtocolConnKeepsReadDeadline(t *testing.T) {
+	clientConn, serverConn := net.Pipe()
+	defer clientConn.Close()
+
+	go clientConn.Write([]byte("PROXY TCP4 192.0.2.1 192.0.2.2 56324 443\r\n"))
+
+	conn := newProxyProtocolConn(serverConn)
+	defer conn.Close()
+
+	if err := conn.SetReadDeadline(time.Now().Add(50 * time.Millisecond)); err != nil {
+		t.Fatal(err)
+	}
+
+	if addrString(conn.RemoteAddr()) != "192.0.2.1:56324" {
+		t.Fatalf("unexpected remote address %s", conn.RemoteAddr())
+	}
+
+	// Nothing more is written: the deadline
+	// set before the header read must still apply
+	_, err := conn.Read(make([]byte, 1))
+
+	if !isConnTimeoutErr(err) {
+		t.Fatalf("expected a timeout, got %v", err)
+	}
+}
+
+func addrString(addr net.Addr) string {
+	if addr == nil {
+		return ""
+	}
+
+	return addr.String()
+}
@@ -0,0 +1,247 @@
+package network
+
+import (
+	"context"
+	"log/slog"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Number of closed connections kept per port
+const maxRecentProxyConnsPerPort = 32
+
+type ProxyConnCloseReason string
+
+const (
+	ProxyConnCloseReasonHostClosed    ProxyConnCloseReason = "host_closed"
+	ProxyConnCloseReasonServiceClosed ProxyConnCloseReason = "service_closed"
+	ProxyConnCloseReasonLingerTimeout ProxyConnCloseReason = "linger_timeout"
+	ProxyConnCloseReasonIdleTimeout   ProxyConnCloseReason = "idle_timeout"
+	ProxyConnCloseReasonError         ProxyConnCloseReason = "error"
+)
+
+type ProxyConnStats struct {
+	RemoteAddr    string
+	BytesInbound  uint64
+	BytesOutbound uint64
+	StartedAt     time.Time
+	Duration      time.Duration
+	CloseReason   ProxyConnCloseReason
+	CloseError    string
+}
+
+type ProxyPortStats struct {
+	ListeningPort uint64
+	ActiveConns   uint64
+	TotalConns    uint64
+	FailedConns   uint64
+	// Rejected because of the connection limits
+	RejectedConns      uint64
+	BytesInbound       uint64
+	BytesOutbound      uint64
+	TotalConnsDuration time.Duration
+	// Most recent first
+	RecentConns []ProxyConnStats
+}
+
+// proxyConnAccounting tracks a connection
+// forwarded by a localhost proxy while it is open.
+// Bytes counters are updated by the forwarding goroutines.
+type proxyConnAccounting struct {
+	listeningPort uint64
+	remoteAddr    string
+	startedAt     time.Time
+	bytesInbound  uint64
+	bytesOutbound uint64
+}
+
+func (p *proxyConnAccounting) addBytesInbound(n int) {
+	atomic.AddUint64(&p.bytesInbound, uint64(n))
+}
+
+func (p *proxyConnAccounting) addBytesOutbound(n int) {
+	atomic.AddUint64(&p.bytesOutbound, uint64(n))
+}
+
+type proxiesStatsRegistry struct {
+	mutex     sync.Mutex
+	portStats map[uint64]*ProxyPortStats
+}
+
+func newProxiesStatsRegistry() *proxiesStatsRegistry {
+	return &proxiesStatsRegistry{
+		portStats: map[uint64]*ProxyPortStats{},
+	}
+}
+
+func (p *proxiesStatsRegistry) list() []ProxyPortStats {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	stats := make([]ProxyPortStats, 0, len(p.portStats))
+
+	for _, portStats := range p.portStats {
+		portStatsCopy := *portStats
+		portStatsCopy.RecentConns = append(
+			[]ProxyConnStats{},
+			portStats.RecentConns...,
+		)
+
+		stats = append(stats, portStatsCopy)
+	}
+
+	sort.Slice(stats, func(i, j int) bool {
+		return stats[i].ListeningPort < stats[j].ListeningPort
+	})
+
+	return stats
+}
+
+// prune evicts the stats of the ports that are
+// not forwarded anymore and have no active connections
+// so that short-lived ports don't accumulate.
+func (p *proxiesStatsRegistry) prune(forwardedPorts map[uint64]struct{}) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	for port, portStats := range p.portStats {
+		if _, forwarded := forwardedPorts[port]; forwarded {
+			continue
+		}
+
+		if portStats.ActiveConns > 0 {
+			continue
+		}
+
+		delete(p.portStats, port)
+	}
+}
+
+// Must be called with the mutex held
+func (p *proxiesStatsRegistry) getOrCreatePortStats(
+	listeningPort uint64,
+) *ProxyPortStats {
+
+	portStats, ok := p.portStats[listeningPort]
+
+	if !ok {
+		portStats = &ProxyPortStats{
+			ListeningPort: listeningPort,
+			RecentConns:   []ProxyConnStats{},
+		}
+
+		p.portStats[listeningPort] = portStats
+	}
+
+	return portStats
+}
+
+func (p *proxiesStatsRegistry) connFailed(listeningPort uint64) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.getOrCreatePortStats(listeningPort).FailedConns++
+}
+
+func (p *proxiesStatsRegistry) connRejected(listeningPort uint64) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.getOrCreatePortStats(listeningPort).RejectedConns++
+}
+
+func (p *proxiesStatsRegistry) connOpened(
+	listeningPort uint64,
+	remoteAddr string,
+) *proxyConnAccounting {
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	portStats := p.getOrCreatePortStats(listeningPort)
+
+	portStats.ActiveConns++
+	portStats.TotalConns++
+
+	return &proxyConnAccounting{
+		listeningPort: listeningPort,
+		remoteAddr:    remoteAddr,
+		startedAt:     time.Now(),
+	}
+}
+
+func (p *proxiesStatsRegistry) connClosed(
+	conn *proxyConnAccounting,
+	closeReason ProxyConnCloseReason,
+	closeErr error,
+) {
+
+	connStats := ProxyConnStats{
+		RemoteAddr:    conn.remoteAddr,
+		BytesInbound:  atomic.LoadUint64(&conn.bytesInbound),
+		BytesOutbound: atomic.LoadUint64(&conn.bytesOutbound),
+		StartedAt:     conn.startedAt,
+		Duration:      time.Since(conn.startedAt),
+		CloseReason:   closeReason,
+	}
+
+	if closeErr != nil {
+		connStats.CloseError = closeErr.Error()
+	}
+
+	logger.Log(
+		context.Background(),
+		proxyConnCloseLogLevel(closeReason, closeErr),
+		"proxy connection closed",
+		"port", conn.listeningPort,
+		"remote_addr", connStats.RemoteAddr,
+		"bytes_inbound", connStats.BytesInbound,
+		"bytes_outbound", connStats.BytesOutbound,
+		"duration", connStats.Duration,
+		"close_reason", connStats.CloseReason,
+		"close_error", connStats.CloseError,
+	)
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	portStats := p.getOrCreatePortStats(conn.listeningPort)
+
+	portStats.ActiveConns--
+	portStats.BytesInbound += connStats.BytesInbound
+	portStats.BytesOutbound += connStats.BytesOutbound
+	portStats.TotalConnsDuration += connStats.Duration
+
+	portStats.RecentConns = append(
+		[]ProxyConnStats{connStats},
+		portStats.RecentConns...,
+	)
+
+	if len(portStats.RecentConns) > maxRecentProxyConnsPerPort {
+		portStats.RecentConns = portStats.RecentConns[:maxRecentProxyConnsPerPort]
+	}
+}
+
+// proxyConnCloseLogLevel returns the level of the record
+// logged when a connection is closed: the connections
+// closed abnormally are logged at "info" (timeouts)
+// or "warn" (errors), the others at "debug".
+func proxyConnCloseLogLevel(
+	closeReason ProxyConnCloseReason,
+	closeErr error,
+) slog.Level {
+
+	if closeErr != nil || closeReason == ProxyConnCloseReasonError {
+		return slog.LevelWarn
+	}
+
+	if closeReason == ProxyConnCloseReasonIdleTimeout ||
+		closeReason == ProxyConnCloseReasonLingerTimeout {
+
+		return slog.LevelInfo
+	}
+
+	return slog.LevelDebug
+}
@@ -0,0 +1,122 @@
+package network
+
+import (
+	"errors"
+	"log/slog"
+	"testing"
+)
+
+func TestProxyConnCloseLogLevel(t *testing.T) {
+	testCases := []struct {
+		name          string
+		closeReason   ProxyConnCloseReason
+		closeErr      error
+		expectedLevel slog.Level
+	}{
+		{
+			name:          "host closed",
+			closeReason:   ProxyConnCloseReasonHostClosed,
+			expectedLevel: slog.LevelDebug,
+		},
+		{
+			name:          "service closed",
+			closeReason:   ProxyConnCloseReasonServiceClosed,
+			expectedLevel: slog.LevelDebug,
+		},
+		{
+			name:          "idle timeout",
+			closeReason:   ProxyConnCloseReasonIdleTimeout,
+			expectedLevel: slog.LevelInfo,
+		},
+		{
+			name:          "linger timeout",
+			closeReason:   ProxyConnCloseReasonLingerTimeout,
+			expectedLevel: slog.LevelInfo,
+		},
+		{
+			name:          "error",
+			closeReason:   ProxyConnCloseReasonError,
+			closeErr:      errors.New("connection reset by peer"),
+			expectedLevel: slog.LevelWarn,
+		},
+		{
+			name:          "closed with error",
+			closeReason:   ProxyConnCloseReasonServiceClosed,
+			closeErr:      errors.New("broken pipe"),
+			expectedLevel: slog.LevelWarn,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			level := proxyConnCloseLogLevel(tc.closeReason, tc.closeErr)
+
+			if level != tc.expectedLevel {
+				t.Fatalf("expected %s, got %s", tc.expectedLevel, level)
+			}
+		})
+	}
+}
+
+func TestProxiesStatsRegistryConnClosed(t *testing.T) {
+	statsRegistry := newProxiesStatsRegistry()
+
+	for i := 0; i < maxRecentProxyConnsPerPort+1; i++ {
+		conn := statsRegistry.connOpened(8080, "127.0.0.1:50000")
+
+		conn.addBytesInbound(10)
+		conn.addBytesOutbound(20)
+
+		statsRegistry.connClosed(
+			conn,
+			ProxyConnCloseReasonError,
+			errors.New("connection reset by peer"),
+		)
+	}
+
+	portsStats := statsRegistry.list()
+
+	if len(portsStats) != 1 {
+		t.Fatalf("expected the stats of one port, got %+v", portsStats)
+	}
+
+	portStats := portsStats[0]
+	connsCount := uint64(maxRecentProxyConnsPerPort + 1)
+
+	if portStats.ActiveConns != 0 || portStats.TotalConns != connsCount {
+		t.Fatalf(
+			"expected 0 active and %d total connections, got %d and %d",
+			connsCount,
+			portStats.ActiveConns,
+			portStats.TotalConns,
+		)
+	}
+
+	if portStats.BytesInbound != 10*connsCount ||
+		portStats.BytesOutbound != 20*connsCount {
+
+		t.Fatalf(
+			"expected %d bytes inbound and %d outbound, got %d and %d",
+			10*connsCount,
+			20*connsCount,
+			portStats.BytesInbound,
+			portStats.BytesOutbound,
+		)
+	}
+
+	if len(portStats.RecentConns) != maxRecentProxyConnsPerPort {
+		t.Fatalf(
+			"expected %d recent connections, got %d",
+			maxRecentProxyConnsPerPort,
+			len(portStats.RecentConns),
+		)
+	}
+
+	recentConn := portStats.RecentConns[0]
+
+	if recentConn.CloseReason != ProxyConnCloseReasonError ||
+		recentConn.CloseError != "connection reset by peer" {
+
+		t.Fatalf("expected the close error to be recorded, got %+v", recentConn)
+	}
+}
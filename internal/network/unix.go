@@ -0,0 +1,122 @@
+package network
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/prometheus/procfs"
+)
+
+// Ref: https://elixir.bootlin.com/linux/latest/source/include/uapi/linux/net.h#L48
+const (
+	unixSocketTypeStream    procfs.NetUNIXType  = 1
+	unixSocketFlagListening procfs.NetUNIXFlags = 1 << 16
+)
+
+// UnixSocket is a stream Unix socket
+// that accepts connections in the container.
+type UnixSocket struct {
+	Path  string
+	Inode uint64
+}
+
+func getListeningUnixSockets() ([]UnixSocket, error) {
+	proc, err := procfs.NewFS("/proc")
+	if err != nil {
+		return nil, fmt.Errorf("could not read /proc: %s", err)
+	}
+
+	netUnix, err := proc.NetUNIX()
+	if err != nil {
+		return nil, fmt.Errorf("could not read /proc/net/unix: %s", err)
+	}
+
+	sockets := []UnixSocket{}
+
+	for _, row := range netUnix.Rows {
+		if row.Type != unixSocketTypeStream ||
+			row.Flags&unixSocketFlagListening == 0 {
+
+			continue
+		}
+
+		// Unbound and abstract sockets (eg: "@/tmp/.X11-unix/X0")
+		// could not be reached using a path
+		if !filepath.IsAbs(row.Path) {
+			continue
+		}
+
+		sockets = append(sockets, UnixSocket{
+			Path:  row.Path,
+			Inode: row.Inode,
+		})
+	}
+
+	return sockets, nil
+}
+
+// ListUnixSockets returns the listening Unix sockets
+// located under the configured "UnixSocketsDirs", ordered by path.
+func (p *ProxyManager) ListUnixSockets() ([]UnixSocket, error) {
+	listeningSockets, err := getListeningUnixSockets()
+
+	if err != nil {
+		return nil, err
+	}
+
+	sockets := []UnixSocket{}
+	seenPaths := map[string]bool{}
+
+	for _, socket := range listeningSockets {
+		if seenPaths[socket.Path] ||
+			!isPathInDirs(socket.Path, p.config.UnixSocketsDirs) {
+
+			continue
+		}
+
+		seenPaths[socket.Path] = true
+		sockets = append(sockets, socket)
+	}
+
+	sort.Slice(sockets, func(i, j int) bool {
+		return sockets[i].Path < sockets[j].Path
+	})
+
+	return sockets, nil
+}
+
+// IsListeningUnixSocket returns true if the path is a listening
+// Unix socket located under the configured "UnixSocketsDirs".
+func (p *ProxyManager) IsListeningUnixSocket(socketPath string) (bool, error) {
+	sockets, err := p.ListUnixSockets()
+
+	if err != nil {
+		return false, err
+	}
+
+	for _, socket := range sockets {
+		if socket.Path == filepath.Clean(socketPath) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func isPathInDirs(path string, dirs []string) bool {
+	cleanedPath := filepath.Clean(path)
+
+	for _, dir := range dirs {
+		cleanedDir := filepath.Clean(dir)
+
+		if cleanedDir == "/" ||
+			strings.HasPrefix(cleanedPath, cleanedDir+string(filepath.Separator)) {
+
+			return true
+		}
+	}
+
+	return false
+}
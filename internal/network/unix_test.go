@@ -0,0 +1,169 @@
+package network
+
+import (
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestIsPathInDirs(t *testing.T) {
+	testCases := []struct {
+		name           string
+		path           string
+		dirs           []string
+		expectedInDirs bool
+	}{
+		{
+			name:           "in dir",
+			path:           "/tmp/app.sock",
+			dirs:           []string{"/run", "/tmp"},
+			expectedInDirs: true,
+		},
+		{
+			name:           "in sub dir",
+			path:           "/run/docker/docker.sock",
+			dirs:           []string{"/run"},
+			expectedInDirs: true,
+		},
+		{
+			name:           "dir with trailing slash",
+			path:           "/tmp/app.sock",
+			dirs:           []string{"/tmp/"},
+			expectedInDirs: true,
+		},
+		{
+			name:           "root dir",
+			path:           "/app.sock",
+			dirs:           []string{"/"},
+			expectedInDirs: true,
+		},
+		{
+			name:           "dir prefix",
+			path:           "/tmpfoo/app.sock",
+			dirs:           []string{"/tmp"},
+			expectedInDirs: false,
+		},
+		{
+			name:           "dir itself",
+			path:           "/tmp",
+			dirs:           []string{"/tmp"},
+			expectedInDirs: false,
+		},
+		{
+			name:           "path traversal",
+			path:           "/tmp/../etc/app.sock",
+			dirs:           []string{"/tmp"},
+			expectedInDirs: false,
+		},
+		{
+			name:           "no dirs",
+			path:           "/tmp/app.sock",
+			dirs:           []string{},
+			expectedInDirs: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if inDirs := isPathInDirs(tc.path, tc.dirs); inDirs != tc.expectedInDirs {
+				t.Fatalf("expected %t, got %t", tc.expectedInDirs, inDirs)
+			}
+		})
+	}
+}
+
+func TestListUnixSockets(t *testing.T) {
+	socketsDirPath := createTestSocketsDir(t)
+	otherSocketsDirPath := createTestSocketsDir(t)
+
+	socketPath := listenTestUnixSocket(t, socketsDirPath, "app.sock")
+	otherSocketPath := listenTestUnixSocket(t, otherSocketsDirPath, "app.sock")
+
+	config := NewDefaultProxyManagerConfig()
+	config.UnixSocketsDirs = []string{socketsDirPath}
+
+	proxyManager := NewProxyManager(config)
+
+	sockets, err := proxyManager.ListUnixSockets()
+
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	if len(sockets) != 1 || sockets[0].Path != socketPath {
+		t.Fatalf("expected the socket %s only, got %+v", socketPath, sockets)
+	}
+
+	testCases := []struct {
+		name              string
+		socketPath        string
+		expectedListening bool
+	}{
+		{
+			name:              "listening",
+			socketPath:        socketPath,
+			expectedListening: true,
+		},
+		{
+			name:              "not cleaned",
+			socketPath:        filepath.Join(socketsDirPath, ".", "app.sock"),
+			expectedListening: true,
+		},
+		{
+			name:              "outside of the dirs",
+			socketPath:        otherSocketPath,
+			expectedListening: false,
+		},
+		{
+			name:              "not a socket",
+			socketPath:        filepath.Join(socketsDirPath, "unknown.sock"),
+			expectedListening: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			listening, err := proxyManager.IsListeningUnixSocket(tc.socketPath)
+
+			if err != nil {
+				t.Fatalf("unexpected error %v", err)
+			}
+
+			if listening != tc.expectedListening {
+				t.Fatalf("expected %t, got %t", tc.expectedListening, listening)
+			}
+		})
+	}
+}
+
+// The Unix socket paths are limited to 108 bytes
+// so the temporary directory is not the one of the test
+func createTestSocketsDir(t *testing.T) string {
+	t.Helper()
+
+	dirPath, err := os.MkdirTemp("", "unix-sockets")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() { os.RemoveAll(dirPath) })
+
+	return dirPath
+}
+
+func listenTestUnixSocket(t *testing.T, dirPath, name string) string {
+	t.Helper()
+
+	socketPath := filepath.Join(dirPath, name)
+	listener, err := net.Listen("unix", socketPath)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() { listener.Close() })
+
+	return socketPath
+}
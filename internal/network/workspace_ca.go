@@ -0,0 +1,499 @@
+package network
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	ErrTLSTerminationDisabled = errors.New("TLS termination is disabled")
+	ErrServerNameNotPermitted = errors.New("server name not permitted by the workspace CA")
+)
+
+// The names the CA could issue certificates for, enforced
+// by the clients via the (critical) name constraints so that
+// trusting the CA doesn't let it impersonate other hosts.
+// The workspace hostnames ("<port>.<workspace>.localhost")
+// are under ".localhost".
+var workspaceCAPermittedDNSDomains = []string{
+	"localhost",
+	".localhost",
+}
+
+// The addresses the CA could issue certificates for:
+// the loopback ones, completed by the
+// container networks on creation
+var workspaceCAPermittedIPRanges = []string{
+	"127.0.0.0/8",
+	"::1/128",
+}
+
+// The private ranges permitted by the CAs generated before
+// the IP constraints were restricted to the container networks
+var workspaceCALegacyIPRanges = []string{
+	"10.0.0.0/8",
+	"172.16.0.0/12",
+	"192.168.0.0/16",
+	"fc00::/7",
+}
+
+const (
+	workspaceCAValidity = 10 * 365 * 24 * time.Hour
+	leafCertValidity    = 30 * 24 * time.Hour
+	// Leaf certificates are renewed
+	// when they expire in less than this
+	leafCertRenewBefore = 24 * time.Hour
+	// Used when the client doesn't send SNI
+	// (eg: when connecting using an IP address)
+	leafCertDefaultServerName = "localhost"
+	// The cache is reset when reached
+	// to bound the memory used by arbitrary SNI values
+	maxCachedLeafCerts = 256
+)
+
+// WorkspaceCA is a local certificate authority generated
+// once per workspace. It issues, on demand, the leaf certificates
+// used to terminate TLS for the forwarded ports so that
+// the host only needs to trust the CA certificate
+// (see CertificatePEM). Safe for concurrent use.
+type WorkspaceCA struct {
+	cert    *x509.Certificate
+	certPEM []byte
+	key     crypto.Signer
+
+	mutex     sync.Mutex
+	leafCerts map[string]*tls.Certificate
+}
+
+// LoadOrCreateWorkspaceCA loads the CA from the passed files
+// or generates (and persists) a new one when they don't exist.
+func LoadOrCreateWorkspaceCA(
+	certFilePath string,
+	keyFilePath string,
+) (*WorkspaceCA, error) {
+
+	certPEM, err := os.ReadFile(certFilePath)
+
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+
+	if err != nil { // Not exists
+		return createWorkspaceCA(certFilePath, keyFilePath)
+	}
+
+	keyPEM, err := os.ReadFile(keyFilePath)
+
+	if err != nil {
+		return nil, err
+	}
+
+	workspaceCA, err := parseWorkspaceCA(certPEM, keyPEM)
+
+	if err != nil {
+		return nil, err
+	}
+
+	// Created before the name constraints were added
+	if !workspaceCA.cert.PermittedDNSDomainsCritical {
+		logger.Warn(
+			"workspace CA without name constraints, regenerating it",
+			"cert_file_path", certFilePath,
+		)
+
+		return createWorkspaceCA(certFilePath, keyFilePath)
+	}
+
+	if hasWorkspaceCALegacyIPRanges(workspaceCA.cert) {
+		logger.Warn(
+			"workspace CA permitting all the private ranges, regenerating it",
+			"cert_file_path", certFilePath,
+		)
+
+		return createWorkspaceCA(certFilePath, keyFilePath)
+	}
+
+	return workspaceCA, nil
+}
+
+func hasWorkspaceCALegacyIPRanges(cert *x509.Certificate) bool {
+	for _, permittedIPRange := range cert.PermittedIPRanges {
+		for _, legacyIPRange := range workspaceCALegacyIPRanges {
+			if permittedIPRange.String() == legacyIPRange {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func createWorkspaceCA(
+	certFilePath string,
+	keyFilePath string,
+) (*WorkspaceCA, error) {
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+
+	if err != nil {
+		return nil, err
+	}
+
+	serialNumber, err := generateCertSerialNumber()
+
+	if err != nil {
+		return nil, err
+	}
+
+	permittedIPRanges, err := buildWorkspaceCAPermittedIPRanges()
+
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+
+	certTemplate := &x509.Certificate{
+		SerialNumber: serialNumber,
+		Subject: pkix.Name{
+			Organization: []string{"Yolo"},
+			CommonName:   "Yolo Workspace CA",
+		},
+		NotBefore:                   now.Add(-time.Hour),
+		NotAfter:                    now.Add(workspaceCAValidity),
+		KeyUsage:                    x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+		BasicConstraintsValid:       true,
+		IsCA:                        true,
+		MaxPathLenZero:              true,
+		PermittedDNSDomainsCritical: true,
+		PermittedDNSDomains:         workspaceCAPermittedDNSDomains,
+		PermittedIPRanges:           permittedIPRanges,
+	}
+
+	certDER, err := x509.CreateCertificate(
+		rand.Reader,
+		certTemplate,
+		certTemplate,
+		key.Public(),
+		key,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+
+	if err != nil {
+		return nil, err
+	}
+
+	certPEM := pem.EncodeToMemory(&pem.Block{
+		Type:  "CERTIFICATE",
+		Bytes: certDER,
+	})
+
+	keyPEM := pem.EncodeToMemory(&pem.Block{
+		Type:  "PRIVATE KEY",
+		Bytes: keyDER,
+	})
+
+	// The key is written first so that a crash
+	// never leaves a certificate without its key
+	if err := writeWorkspaceCAFile(keyFilePath, keyPEM, 0600); err != nil {
+		return nil, err
+	}
+
+	if err := writeWorkspaceCAFile(certFilePath, certPEM, 0660); err != nil {
+		return nil, err
+	}
+
+	return parseWorkspaceCA(certPEM, keyPEM)
+}
+
+func buildWorkspaceCAPermittedIPRanges() ([]*net.IPNet, error) {
+	permittedIPRanges := []*net.IPNet{}
+
+	for _, ipRange := range workspaceCAPermittedIPRanges {
+		_, ipNet, err := net.ParseCIDR(ipRange)
+
+		if err != nil {
+			return nil, err
+		}
+
+		permittedIPRanges = append(permittedIPRanges, ipNet)
+	}
+
+	containerIPNets, err := getContainerIPNets()
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, containerIPNet := range containerIPNets {
+		if isIPPermitted(containerIPNet.IP, permittedIPRanges) ||
+			containerIPNet.IP.IsLinkLocalUnicast() {
+
+			continue
+		}
+
+		permittedIPRanges = append(permittedIPRanges, containerIPNet)
+	}
+
+	return permittedIPRanges, nil
+}
+
+func isIPPermitted(ip net.IP, permittedIPRanges []*net.IPNet) bool {
+	for _, ipRange := range permittedIPRanges {
+		if ipRange.Contains(ip) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isDNSNamePermitted matches the name against the
+// constraints like the clients do (RFC 5280): a domain
+// matches itself and its subdomains, a domain starting
+// with a period only matches its subdomains.
+func isDNSNamePermitted(name string, permittedDNSDomains []string) bool {
+	for _, domain := range permittedDNSDomains {
+		if strings.HasPrefix(domain, ".") {
+			if strings.HasSuffix(name, domain) {
+				return true
+			}
+
+			continue
+		}
+
+		if name == domain || strings.HasSuffix(name, "."+domain) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func writeWorkspaceCAFile(
+	filePath string,
+	content []byte,
+	fileMode os.FileMode,
+) error {
+
+	err := os.MkdirAll(filepath.Dir(filePath), os.FileMode(0770))
+
+	if err != nil {
+		return err
+	}
+
+	err = os.WriteFile(filePath, content, fileMode)
+
+	if err != nil {
+		return err
+	}
+
+	// Overwrite umask.
+	// See: https://stackoverflow.com/questions/50257981/ioutils-writefile-not-respecting-permissions
+	return os.Chmod(filePath, fileMode)
+}
+
+func parseWorkspaceCA(certPEM, keyPEM []byte) (*WorkspaceCA, error) {
+	certBlock, _ := pem.Decode(certPEM)
+
+	if certBlock == nil || certBlock.Type != "CERTIFICATE" {
+		return nil, fmt.Errorf("invalid workspace CA certificate")
+	}
+
+	cert, err := x509.ParseCertificate(certBlock.Bytes)
+
+	if err != nil {
+		return nil, err
+	}
+
+	keyBlock, _ := pem.Decode(keyPEM)
+
+	if keyBlock == nil {
+		return nil, fmt.Errorf("invalid workspace CA key")
+	}
+
+	parsedKey, err := x509.ParsePKCS8PrivateKey(keyBlock.Bytes)
+
+	if err != nil {
+		return nil, err
+	}
+
+	key, ok := parsedKey.(crypto.Signer)
+
+	if !ok {
+		return nil, fmt.Errorf("unsupported workspace CA key type %T", parsedKey)
+	}
+
+	return &WorkspaceCA{
+		cert:      cert,
+		certPEM:   certPEM,
+		key:       key,
+		leafCerts: map[string]*tls.Certificate{},
+	}, nil
+}
+
+// CertificatePEM returns the PEM-encoded CA certificate
+// that the host needs to trust.
+func (w *WorkspaceCA) CertificatePEM() []byte {
+	return w.certPEM
+}
+
+// TLSConfig returns a server configuration
+// that issues the leaf certificates on demand.
+// No ALPN protocol is advertised: the forwarded
+// services are unaware of the TLS termination.
+func (w *WorkspaceCA) TLSConfig() *tls.Config {
+	return &tls.Config{
+		MinVersion:     tls.VersionTLS12,
+		GetCertificate: w.GetCertificate,
+	}
+}
+
+// GetCertificate returns the leaf certificate for the
+// requested server name, issuing it when needed.
+// Issued certificates are cached in memory only.
+// The server names outside of the CA name constraints
+// are refused (the clients would reject the certificate).
+func (w *WorkspaceCA) GetCertificate(
+	hello *tls.ClientHelloInfo,
+) (*tls.Certificate, error) {
+
+	serverName := strings.ToLower(strings.TrimSuffix(hello.ServerName, "."))
+
+	if len(serverName) == 0 {
+		serverName = leafCertDefaultServerName
+	}
+
+	if !isDNSNamePermitted(serverName, w.cert.PermittedDNSDomains) {
+		return nil, fmt.Errorf("%w: %q", ErrServerNameNotPermitted, serverName)
+	}
+
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	leafCert, ok := w.leafCerts[serverName]
+
+	if ok && time.Until(leafCert.Leaf.NotAfter) > leafCertRenewBefore {
+		return leafCert, nil
+	}
+
+	leafCert, err := w.issueLeafCert(serverName)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(w.leafCerts) >= maxCachedLeafCerts {
+		w.leafCerts = map[string]*tls.Certificate{}
+	}
+
+	w.leafCerts[serverName] = leafCert
+
+	return leafCert, nil
+}
+
+func (w *WorkspaceCA) issueLeafCert(serverName string) (*tls.Certificate, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+
+	if err != nil {
+		return nil, err
+	}
+
+	serialNumber, err := generateCertSerialNumber()
+
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+
+	certTemplate := &x509.Certificate{
+		SerialNumber: serialNumber,
+		Subject: pkix.Name{
+			Organization: []string{"Yolo"},
+			CommonName:   serverName,
+		},
+		NotBefore:   now.Add(-time.Hour),
+		NotAfter:    now.Add(leafCertValidity),
+		KeyUsage:    x509.KeyUsageDigitalSignature,
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+
+	certTemplate.DNSNames = []string{serverName}
+
+	// Clients connecting using an IP address (eg: "https://172.20.0.2:3000")
+	// don't send SNI and get the default certificate
+	if serverName == leafCertDefaultServerName {
+		certTemplate.IPAddresses = []net.IP{
+			net.IPv4(127, 0, 0, 1),
+			net.IPv6loopback,
+		}
+
+		containerIPs, err := getContainerIPs()
+
+		if err != nil {
+			return nil, err
+		}
+
+		for _, containerIP := range containerIPs {
+			// Outside of the CA name constraints
+			// (eg: the container network has changed)
+			if !isIPPermitted(containerIP, w.cert.PermittedIPRanges) {
+				continue
+			}
+
+			certTemplate.IPAddresses = append(
+				certTemplate.IPAddresses,
+				containerIP,
+			)
+		}
+	}
+
+	certDER, err := x509.CreateCertificate(
+		rand.Reader,
+		certTemplate,
+		w.cert,
+		key.Public(),
+		w.key,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	leaf, err := x509.ParseCertificate(certDER)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &tls.Certificate{
+		Certificate: [][]byte{certDER, w.cert.Raw},
+		PrivateKey:  key,
+		Leaf:        leaf,
+	}, nil
+}
+
+func generateCertSerialNumber() (*big.Int, error) {
+	return rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+}
@@ -0,0 +1,244 @@
+package network
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestIsDNSNamePermitted(t *testing.T) {
+	testCases := []struct {
+		name              string
+		dnsName           string
+		expectedPermitted bool
+	}{
+		{
+			name:              "domain itself",
+			dnsName:           "localhost",
+			expectedPermitted: true,
+		},
+		{
+			name:              "subdomain",
+			dnsName:           "3000.localhost",
+			expectedPermitted: true,
+		},
+		{
+			name:              "workspace subdomain",
+			dnsName:           "3000.workspace.localhost",
+			expectedPermitted: true,
+		},
+		{
+			name:              "domain suffix",
+			dnsName:           "notlocalhost",
+			expectedPermitted: false,
+		},
+		{
+			name:              "other domain",
+			dnsName:           "example.com",
+			expectedPermitted: false,
+		},
+		{
+			name:              "domain as subdomain",
+			dnsName:           "localhost.example.com",
+			expectedPermitted: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			permitted := isDNSNamePermitted(tc.dnsName, workspaceCAPermittedDNSDomains)
+
+			if permitted != tc.expectedPermitted {
+				t.Fatalf("expected %t, got %t", tc.expectedPermitted, permitted)
+			}
+		})
+	}
+}
+
+func TestLoadOrCreateWorkspaceCA(t *testing.T) {
+	caDirPath := filepath.Join(t.TempDir(), "tls")
+	certFilePath := filepath.Join(caDirPath, "ca.crt")
+	keyFilePath := filepath.Join(caDirPath, "ca.key")
+
+	workspaceCA, err := LoadOrCreateWorkspaceCA(certFilePath, keyFilePath)
+
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	keyFileInfo, err := os.Stat(keyFilePath)
+
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	if keyFileInfo.Mode().Perm() != 0600 {
+		t.Fatalf("expected the key file mode 0600, got %o", keyFileInfo.Mode().Perm())
+	}
+
+	loadedWorkspaceCA, err := LoadOrCreateWorkspaceCA(certFilePath, keyFilePath)
+
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	if !bytes.Equal(loadedWorkspaceCA.CertificatePEM(), workspaceCA.CertificatePEM()) {
+		t.Fatal("expected the persisted CA to be loaded")
+	}
+
+	if !loadedWorkspaceCA.cert.PermittedDNSDomainsCritical {
+		t.Fatal("expected critical name constraints")
+	}
+
+	if hasWorkspaceCALegacyIPRanges(loadedWorkspaceCA.cert) {
+		t.Fatalf("expected no private ranges, got %v", loadedWorkspaceCA.cert.PermittedIPRanges)
+	}
+}
+
+func TestHasWorkspaceCALegacyIPRanges(t *testing.T) {
+	testCases := []struct {
+		name           string
+		ipRanges       []string
+		expectedLegacy bool
+	}{
+		{
+			name:           "loopback and container networks",
+			ipRanges:       []string{"127.0.0.0/8", "::1/128", "172.20.0.0/16"},
+			expectedLegacy: false,
+		},
+		{
+			name:           "private IPv4 range",
+			ipRanges:       []string{"127.0.0.0/8", "172.16.0.0/12"},
+			expectedLegacy: true,
+		},
+		{
+			name:           "unique local IPv6 range",
+			ipRanges:       []string{"::1/128", "fc00::/7"},
+			expectedLegacy: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cert := &x509.Certificate{}
+
+			for _, ipRange := range tc.ipRanges {
+				_, ipNet, err := net.ParseCIDR(ipRange)
+
+				if err != nil {
+					t.Fatalf("unexpected error %v", err)
+				}
+
+				cert.PermittedIPRanges = append(cert.PermittedIPRanges, ipNet)
+			}
+
+			if legacy := hasWorkspaceCALegacyIPRanges(cert); legacy != tc.expectedLegacy {
+				t.Fatalf("expected %t, got %t", tc.expectedLegacy, legacy)
+			}
+		})
+	}
+}
+
+func TestWorkspaceCAGetCertificate(t *testing.T) {
+	caDirPath := t.TempDir()
+
+	workspaceCA, err := LoadOrCreateWorkspaceCA(
+		filepath.Join(caDirPath, "ca.crt"),
+		filepath.Join(caDirPath, "ca.key"),
+	)
+
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	roots := x509.NewCertPool()
+	roots.AppendCertsFromPEM(workspaceCA.CertificatePEM())
+
+	testCases := []struct {
+		name            string
+		serverName      string
+		expectedDNSName string
+		expectedIP      net.IP
+		expectedError   error
+	}{
+		{
+			name:            "workspace hostname",
+			serverName:      "3000.workspace.localhost",
+			expectedDNSName: "3000.workspace.localhost",
+		},
+		{
+			name:            "case and trailing dot",
+			serverName:      "3000.Workspace.localhost.",
+			expectedDNSName: "3000.workspace.localhost",
+		},
+		{
+			name:            "no SNI",
+			serverName:      "",
+			expectedDNSName: "localhost",
+			expectedIP:      net.IPv4(127, 0, 0, 1),
+		},
+		{
+			name:          "not permitted",
+			serverName:    "example.com",
+			expectedError: ErrServerNameNotPermitted,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			leafCert, err := workspaceCA.GetCertificate(&tls.ClientHelloInfo{
+				ServerName: tc.serverName,
+			})
+
+			if tc.expectedError != nil {
+				if !errors.Is(err, tc.expectedError) {
+					t.Fatalf("expected %v, got %v", tc.expectedError, err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error %v", err)
+			}
+
+			// The name constraints are verified too
+			_, err = leafCert.Leaf.Verify(x509.VerifyOptions{
+				DNSName: tc.expectedDNSName,
+				Roots:   roots,
+			})
+
+			if err != nil {
+				t.Fatalf("unexpected error %v", err)
+			}
+
+			if tc.expectedIP != nil {
+				_, err = leafCert.Leaf.Verify(x509.VerifyOptions{
+					DNSName: tc.expectedIP.String(),
+					Roots:   roots,
+				})
+
+				if err != nil {
+					t.Fatalf("unexpected error %v", err)
+				}
+			}
+
+			cachedLeafCert, err := workspaceCA.GetCertificate(&tls.ClientHelloInfo{
+				ServerName: tc.serverName,
+			})
+
+			if err != nil {
+				t.Fatalf("unexpected error %v", err)
+			}
+
+			if cachedLeafCert != leafCert {
+				t.Fatal("expected the certificate to be cached")
+			}
+		})
+	}
+}
@@ -0,0 +1,42 @@
+package system
+
+import (
+	"context"
+	"os/exec"
+	"syscall"
+	"time"
+)
+
+// How long "Wait" waits for the output to be copied once
+// the command has been killed (eg: when one of its processes has
+// left the process group and still holds the pipes). Only effective
+// when "Stdout" / "Stderr" are not "*os.File" and are not read
+// directly (via "StdoutPipe" / "StderrPipe") given that the
+// copy is then done by "Wait".
+const commandWaitDelay = 5 * time.Second
+
+// CommandContext is like "exec.CommandContext" except that
+// the whole process group of the command is killed once the
+// context is done so that the processes it has started
+// (eg: "ssh" for "git clone") don't outlive it.
+func CommandContext(
+	ctx context.Context,
+	name string,
+	args ...string,
+) *exec.Cmd {
+
+	cmd := exec.CommandContext(ctx, name, args...)
+
+	cmd.SysProcAttr = &syscall.SysProcAttr{
+		Setpgid: true,
+	}
+
+	cmd.Cancel = func() error {
+		// The process group ID is the PID of the command
+		return syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+	}
+
+	cmd.WaitDelay = commandWaitDelay
+
+	return cmd
+}
@@ -0,0 +1,30 @@
+package system
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+)
+
+func TestCommandContextKillsProcessGroup(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	// The background "sleep" inherits the output pipe.
+	// "Wait" would wait for it if it was not killed too.
+	cmd := CommandContext(ctx, "sh", "-c", "sleep 30 & sleep 30")
+
+	var output bytes.Buffer
+	cmd.Stdout = &output
+
+	startedAt := time.Now()
+
+	if err := cmd.Run(); err == nil {
+		t.Fatal("expected the command to be killed")
+	}
+
+	if duration := time.Since(startedAt); duration > commandWaitDelay/2 {
+		t.Fatalf("expected the command to be killed with its children, took %s", duration)
+	}
+}
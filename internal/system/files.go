@@ -48,3 +48,16 @@ func (FileManager) RemoveDirContent(dirPath string) error {
 
 	return nil
 }
+
+// EnsurePrivateDir creates the directory (and its parents)
+// and makes sure that only its owner could access it,
+// even if it already exists.
+func EnsurePrivateDir(dirPath string) error {
+	if err := os.MkdirAll(dirPath, 0700); err != nil {
+		return err
+	}
+
+	// Overwrite umask (and the mode of an existing directory).
+	// See: https://stackoverflow.com/questions/50257981/ioutils-writefile-not-respecting-permissions
+	return os.Chmod(dirPath, 0700)
+}
@@ -0,0 +1,56 @@
+package system
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestEnsurePrivateDir(t *testing.T) {
+	testCases := []struct {
+		name   string
+		exists bool
+	}{
+		{
+			name: "missing directory",
+		},
+		{
+			name:   "existing directory",
+			exists: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			dirPath := filepath.Join(t.TempDir(), "run", "private")
+
+			if tc.exists {
+				if err := os.MkdirAll(dirPath, 0755); err != nil {
+					t.Fatalf("unexpected error %v", err)
+				}
+
+				if err := os.Chmod(dirPath, 0755); err != nil {
+					t.Fatalf("unexpected error %v", err)
+				}
+			}
+
+			if err := EnsurePrivateDir(dirPath); err != nil {
+				t.Fatalf("unexpected error %v", err)
+			}
+
+			dirInfo, err := os.Stat(dirPath)
+
+			if err != nil {
+				t.Fatalf("unexpected error %v", err)
+			}
+
+			if !dirInfo.IsDir() {
+				t.Fatalf("expected %s to be a directory", dirPath)
+			}
+
+			if mode := dirInfo.Mode().Perm(); mode != 0700 {
+				t.Fatalf("expected mode 0700, got %o", mode)
+			}
+		})
+	}
+}
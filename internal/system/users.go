@@ -0,0 +1,41 @@
+package system
+
+import (
+	"errors"
+	"os"
+	"os/user"
+	"strconv"
+
+	"github.com/yolo-sh/agent-container/constants"
+)
+
+// ChownToYoloUser gives the file (eg: a Unix socket
+// served by the agent) to the "yolo" user.
+func ChownToYoloUser(filePath string) error {
+	yoloUser, err := user.Lookup(constants.YoloUserName)
+
+	if err != nil {
+		return err
+	}
+
+	uid, err := strconv.Atoi(yoloUser.Uid)
+
+	if err != nil {
+		return err
+	}
+
+	gid, err := strconv.Atoi(yoloUser.Gid)
+
+	if err != nil {
+		return err
+	}
+
+	err = os.Chown(filePath, uid, gid)
+
+	// Not running as root
+	if errors.Is(err, os.ErrPermission) && os.Getuid() == uid {
+		return nil
+	}
+
+	return err
+}
@@ -0,0 +1,342 @@
+package tunnel
+
+import (
+	"errors"
+	"io"
+	"net"
+	"sync"
+
+	"github.com/yolo-sh/agent-container/internal/logging"
+	"github.com/yolo-sh/agent-container/proto"
+)
+
+var logger = logging.Component(logging.ComponentGRPC)
+
+// ConnFrameStream abstracts the gRPC streams
+// that multiplex several connections.
+// Send and Recv are never called concurrently with themselves.
+type ConnFrameStream interface {
+	SendConnFrame(frame *proto.TunnelConnFrame) error
+	// Returns io.EOF when the peer has closed its sending side
+	RecvConnFrame() (*proto.TunnelConnFrame, error)
+}
+
+type listenerMux struct {
+	stream    ConnFrameStream
+	sendMutex sync.Mutex
+
+	mutex      sync.Mutex
+	conns      map[uint64]*muxConn
+	nextConnID uint64
+}
+
+type muxConn struct {
+	id         uint64
+	conn       net.Conn
+	sendWindow *sendWindow
+	writeQueue *writeQueue
+	// Protected by the mux mutex
+	readDone  bool
+	writeDone bool
+}
+
+// ServeListener multiplexes the connections accepted by
+// "listener" over "stream": an "open" frame is sent
+// for each accepted connection, then the bytes are forwarded
+// in both directions with a per-connection flow control.
+// The peer is expected to dial its side of the connection
+// when receiving an "open" frame. ServeListener returns once
+// the stream or the listener fails. The listener and all the
+// connections are closed when it returns.
+func ServeListener(listener net.Listener, stream ConnFrameStream) error {
+	mux := &listenerMux{
+		stream: stream,
+		conns:  map[uint64]*muxConn{},
+	}
+
+	acceptErrChan := make(chan error, 1)
+	recvErrChan := make(chan error, 1)
+
+	go func() {
+		acceptErrChan <- mux.acceptConns(listener)
+	}()
+
+	go func() {
+		recvErrChan <- mux.recvConnFrames()
+	}()
+
+	var err error
+
+	select {
+	case err = <-acceptErrChan:
+	case err = <-recvErrChan:
+	}
+
+	listener.Close()
+	mux.closeAllConns()
+
+	if err != nil && errors.Is(err, io.EOF) {
+		return nil
+	}
+
+	return err
+}
+
+func (l *listenerMux) send(frame *proto.TunnelConnFrame) error {
+	l.sendMutex.Lock()
+	defer l.sendMutex.Unlock()
+
+	return l.stream.SendConnFrame(frame)
+}
+
+func (l *listenerMux) acceptConns(listener net.Listener) error {
+	for {
+		conn, err := listener.Accept()
+
+		if err != nil {
+			return err
+		}
+
+		muxConn := l.registerConn(conn)
+
+		err = l.send(&proto.TunnelConnFrame{
+			ConnId: muxConn.id,
+			Open:   true,
+		})
+
+		if err != nil {
+			return err
+		}
+
+		go l.forwardConnToStream(muxConn)
+		go l.forwardQueueToConn(muxConn)
+	}
+}
+
+func (l *listenerMux) recvConnFrames() error {
+	for {
+		frame, err := l.stream.RecvConnFrame()
+
+		if err != nil {
+			return err
+		}
+
+		muxConn := l.lookupConn(frame.ConnId)
+
+		if muxConn == nil { // Already closed
+			continue
+		}
+
+		if frame.WindowUpdate > 0 {
+			muxConn.sendWindow.release(int(frame.WindowUpdate))
+		}
+
+		if frame.Close {
+			if len(frame.Error) > 0 {
+				logger.Debug(
+					"tunneled connection closed by peer",
+					"conn_id", frame.ConnId,
+					"error", frame.Error,
+				)
+			}
+
+			l.closeConn(muxConn)
+			continue
+		}
+
+		if frame.Frame == nil {
+			continue
+		}
+
+		if len(frame.Frame.Data) > 0 {
+			muxConn.writeQueue.push(frame.Frame.Data)
+		}
+
+		if frame.Frame.CloseWrite {
+			muxConn.writeQueue.pushCloseWrite()
+		}
+	}
+}
+
+func (l *listenerMux) forwardConnToStream(muxConn *muxConn) {
+	buffer := make([]byte, frameDataSize)
+
+	for {
+		acquired, err := muxConn.sendWindow.acquire(len(buffer))
+
+		if err != nil { // Connection closed
+			return
+		}
+
+		n, readErr := muxConn.conn.Read(buffer[:acquired])
+		muxConn.sendWindow.release(acquired - n)
+
+		if n > 0 {
+			err := l.send(&proto.TunnelConnFrame{
+				ConnId: muxConn.id,
+				Frame: &proto.TunnelFrame{
+					Data: append([]byte{}, buffer[:n]...),
+				},
+			})
+
+			if err != nil {
+				l.closeConn(muxConn)
+				return
+			}
+		}
+
+		if readErr != nil && errors.Is(readErr, io.EOF) {
+			err := l.send(&proto.TunnelConnFrame{
+				ConnId: muxConn.id,
+				Frame: &proto.TunnelFrame{
+					CloseWrite: true,
+				},
+			})
+
+			if err != nil {
+				l.closeConn(muxConn)
+				return
+			}
+
+			l.markConnReadDone(muxConn)
+			return
+		}
+
+		if readErr != nil {
+			l.abortConn(muxConn, readErr)
+			return
+		}
+	}
+}
+
+func (l *listenerMux) forwardQueueToConn(muxConn *muxConn) {
+	for {
+		data, closeWrite, ok := muxConn.writeQueue.pop()
+
+		if !ok { // Connection closed
+			return
+		}
+
+		if closeWrite {
+			if err := closeConnWrite(muxConn.conn); err != nil {
+				l.abortConn(muxConn, err)
+				return
+			}
+
+			l.markConnWriteDone(muxConn)
+			return
+		}
+
+		if _, err := muxConn.conn.Write(data); err != nil {
+			l.abortConn(muxConn, err)
+			return
+		}
+
+		err := l.send(&proto.TunnelConnFrame{
+			ConnId:       muxConn.id,
+			WindowUpdate: uint32(len(data)),
+		})
+
+		if err != nil {
+			l.closeConn(muxConn)
+			return
+		}
+	}
+}
+
+func (l *listenerMux) registerConn(conn net.Conn) *muxConn {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	l.nextConnID++
+
+	muxConn := &muxConn{
+		id:         l.nextConnID,
+		conn:       conn,
+		sendWindow: newSendWindow(connWindowSize),
+		writeQueue: newWriteQueue(),
+	}
+
+	l.conns[muxConn.id] = muxConn
+
+	return muxConn
+}
+
+func (l *listenerMux) lookupConn(connID uint64) *muxConn {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	return l.conns[connID]
+}
+
+func (l *listenerMux) markConnReadDone(muxConn *muxConn) {
+	l.mutex.Lock()
+	muxConn.readDone = true
+	bothDone := muxConn.writeDone
+	l.mutex.Unlock()
+
+	if bothDone {
+		l.closeConn(muxConn)
+	}
+}
+
+func (l *listenerMux) markConnWriteDone(muxConn *muxConn) {
+	l.mutex.Lock()
+	muxConn.writeDone = true
+	bothDone := muxConn.readDone
+	l.mutex.Unlock()
+
+	if bothDone {
+		l.closeConn(muxConn)
+	}
+}
+
+// abortConn closes the connection
+// and notifies the peer of the error.
+func (l *listenerMux) abortConn(muxConn *muxConn, err error) {
+	if !l.closeConn(muxConn) { // Already closed
+		return
+	}
+
+	// Best effort. The stream may be broken.
+	_ = l.send(&proto.TunnelConnFrame{
+		ConnId: muxConn.id,
+		Close:  true,
+		Error:  err.Error(),
+	})
+}
+
+// closeConn returns false if the connection was already closed.
+func (l *listenerMux) closeConn(muxConn *muxConn) bool {
+	l.mutex.Lock()
+
+	if _, ok := l.conns[muxConn.id]; !ok {
+		l.mutex.Unlock()
+		return false
+	}
+
+	delete(l.conns, muxConn.id)
+	l.mutex.Unlock()
+
+	muxConn.conn.Close()
+	muxConn.sendWindow.close()
+	muxConn.writeQueue.close()
+
+	return true
+}
+
+func (l *listenerMux) closeAllConns() {
+	l.mutex.Lock()
+
+	conns := make([]*muxConn, 0, len(l.conns))
+
+	for _, muxConn := range l.conns {
+		conns = append(conns, muxConn)
+	}
+
+	l.mutex.Unlock()
+
+	for _, muxConn := range conns {
+		l.closeConn(muxConn)
+	}
+}
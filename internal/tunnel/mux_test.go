@@ -0,0 +1,225 @@
+package tunnel
+
+import (
+	"bytes"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/yolo-sh/agent-container/proto"
+)
+
+// fakeConnFrameStream plays the peer of the mux:
+// the frames sent by the mux are pushed to "sent"
+// and the frames pushed to "recv" are received by it.
+type fakeConnFrameStream struct {
+	sent chan *proto.TunnelConnFrame
+	recv chan *proto.TunnelConnFrame
+}
+
+func newFakeConnFrameStream() *fakeConnFrameStream {
+	return &fakeConnFrameStream{
+		sent: make(chan *proto.TunnelConnFrame, 1024),
+		recv: make(chan *proto.TunnelConnFrame, 1024),
+	}
+}
+
+func (f *fakeConnFrameStream) SendConnFrame(frame *proto.TunnelConnFrame) error {
+	f.sent <- frame
+	return nil
+}
+
+func (f *fakeConnFrameStream) RecvConnFrame() (*proto.TunnelConnFrame, error) {
+	frame, ok := <-f.recv
+
+	if !ok {
+		return nil, io.EOF
+	}
+
+	return frame, nil
+}
+
+func (f *fakeConnFrameStream) nextSent(t *testing.T) *proto.TunnelConnFrame {
+	t.Helper()
+
+	select {
+	case frame := <-f.sent:
+		return frame
+	case <-time.After(time.Second):
+		t.Fatal("no frame sent by the mux")
+		return nil
+	}
+}
+
+// nextSentData skips the window updates
+func (f *fakeConnFrameStream) nextSentData(t *testing.T) []byte {
+	t.Helper()
+
+	for {
+		frame := f.nextSent(t)
+
+		if frame.Frame != nil {
+			return frame.Frame.Data
+		}
+	}
+}
+
+func TestServeListener(t *testing.T) {
+	testCases := []struct {
+		name string
+		test func(t *testing.T, conn net.Conn, connID uint64, stream *fakeConnFrameStream)
+	}{
+		{
+			name: "forwards the bytes in both directions",
+			test: func(t *testing.T, conn net.Conn, connID uint64, stream *fakeConnFrameStream) {
+				if _, err := conn.Write([]byte("ping")); err != nil {
+					t.Fatal(err)
+				}
+
+				if data := stream.nextSentData(t); string(data) != "ping" {
+					t.Fatalf("expected \"ping\", got %q", data)
+				}
+
+				stream.recv <- &proto.TunnelConnFrame{
+					ConnId: connID,
+					Frame:  &proto.TunnelFrame{Data: []byte("pong")},
+				}
+
+				buffer := make([]byte, 4)
+
+				if _, err := io.ReadFull(conn, buffer); err != nil {
+					t.Fatal(err)
+				}
+
+				if string(buffer) != "pong" {
+					t.Fatalf("expected \"pong\", got %q", buffer)
+				}
+
+				// The written bytes are acknowledged
+				windowUpdate := stream.nextSent(t)
+
+				if windowUpdate.WindowUpdate != 4 {
+					t.Fatalf("expected a window update of 4, got %+v", windowUpdate)
+				}
+			},
+		},
+		{
+			name: "stops sending once the window is exhausted",
+			test: func(t *testing.T, conn net.Conn, connID uint64, stream *fakeConnFrameStream) {
+				payload := bytes.Repeat([]byte("a"), connWindowSize+frameDataSize)
+
+				go conn.Write(payload)
+
+				sentSize := 0
+
+				for sentSize < connWindowSize {
+					sentSize += len(stream.nextSentData(t))
+				}
+
+				if sentSize != connWindowSize {
+					t.Fatalf("expected %d bytes sent, got %d", connWindowSize, sentSize)
+				}
+
+				select {
+				case frame := <-stream.sent:
+					t.Fatalf("unexpected frame sent without window: %+v", frame)
+				case <-time.After(50 * time.Millisecond):
+				}
+
+				stream.recv <- &proto.TunnelConnFrame{
+					ConnId:       connID,
+					WindowUpdate: frameDataSize,
+				}
+
+				for sentSize < len(payload) {
+					sentSize += len(stream.nextSentData(t))
+				}
+			},
+		},
+		{
+			name: "propagates the half-closes",
+			test: func(t *testing.T, conn net.Conn, connID uint64, stream *fakeConnFrameStream) {
+				if err := conn.(*net.TCPConn).CloseWrite(); err != nil {
+					t.Fatal(err)
+				}
+
+				frame := stream.nextSent(t)
+
+				if frame.Frame == nil || !frame.Frame.CloseWrite {
+					t.Fatalf("expected a close write frame, got %+v", frame)
+				}
+
+				stream.recv <- &proto.TunnelConnFrame{
+					ConnId: connID,
+					Frame:  &proto.TunnelFrame{CloseWrite: true},
+				}
+
+				if _, err := conn.Read(make([]byte, 1)); err != io.EOF {
+					t.Fatalf("expected EOF, got %v", err)
+				}
+			},
+		},
+		{
+			name: "closes the connection closed by the peer",
+			test: func(t *testing.T, conn net.Conn, connID uint64, stream *fakeConnFrameStream) {
+				stream.recv <- &proto.TunnelConnFrame{
+					ConnId: connID,
+					Close:  true,
+					Error:  "connection refused",
+				}
+
+				conn.SetReadDeadline(time.Now().Add(time.Second))
+
+				if _, err := conn.Read(make([]byte, 1)); err != io.EOF {
+					t.Fatalf("expected EOF, got %v", err)
+				}
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			listener, err := net.Listen("tcp", "127.0.0.1:0")
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			stream := newFakeConnFrameStream()
+			serveErrChan := make(chan error, 1)
+
+			go func() {
+				serveErrChan <- ServeListener(listener, stream)
+			}()
+
+			conn, err := net.Dial("tcp", listener.Addr().String())
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			defer conn.Close()
+
+			openFrame := stream.nextSent(t)
+
+			if !openFrame.Open {
+				t.Fatalf("expected an open frame, got %+v", openFrame)
+			}
+
+			tc.test(t, conn, openFrame.ConnId, stream)
+
+			// The peer closes the stream
+			close(stream.recv)
+
+			select {
+			case err := <-serveErrChan:
+				if err != nil {
+					t.Fatalf("unexpected error %v", err)
+				}
+			case <-time.After(time.Second):
+				t.Fatal("ServeListener still running")
+			}
+		})
+	}
+}
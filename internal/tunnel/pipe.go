@@ -0,0 +1,209 @@
+package tunnel
+
+import (
+	"errors"
+	"io"
+	"net"
+	"sync"
+
+	"github.com/yolo-sh/agent-container/proto"
+)
+
+// The maximum number of bytes sent in a frame.
+// Keeps the gRPC messages well below the default 4MB limit.
+const frameDataSize = 32 * 1024
+
+// FrameStream abstracts the gRPC streams
+// that carry the bytes of a single tunneled connection.
+// Send and Recv are never called concurrently with themselves.
+type FrameStream interface {
+	SendFrame(frame *proto.TunnelFrame) error
+	// Returns io.EOF when the peer has closed its sending side
+	RecvFrame() (*proto.TunnelFrame, error)
+}
+
+type pipe struct {
+	conn       net.Conn
+	stream     FrameStream
+	sendMutex  sync.Mutex
+	sendWindow *sendWindow
+	writeQueue *writeQueue
+}
+
+// Pipe forwards the bytes read from "conn" to "stream"
+// and vice versa, until both directions are closed
+// or one of them fails. Half-closes are propagated
+// using the "close_write" field of the frames.
+//
+// Flow control: at most "connWindowSize" bytes are sent
+// before the peer acknowledges them using "window_update".
+// The received bytes are acknowledged once written to "conn",
+// so a slow connection pushes back on the peer.
+// If the peer closes its sending side, it could not acknowledge
+// anything anymore and only the gRPC flow control applies.
+//
+// The connection is closed when Pipe returns.
+func Pipe(conn net.Conn, stream FrameStream) error {
+	p := &pipe{
+		conn:       conn,
+		stream:     stream,
+		sendWindow: newSendWindow(connWindowSize),
+		writeQueue: newWriteQueue(),
+	}
+
+	defer func() {
+		conn.Close()
+		p.sendWindow.close()
+		p.writeQueue.close()
+	}()
+
+	connToStreamChan := make(chan error, 1)
+	queueToConnChan := make(chan error, 1)
+	streamToQueueChan := make(chan error, 1)
+
+	go func() {
+		connToStreamChan <- p.forwardConnToStream()
+	}()
+
+	go func() {
+		queueToConnChan <- p.forwardQueueToConn()
+	}()
+
+	go func() {
+		streamToQueueChan <- p.forwardStreamToQueue()
+	}()
+
+	remainingDirections := 2
+
+	for remainingDirections > 0 {
+		var err error
+
+		select {
+		case err = <-connToStreamChan:
+			remainingDirections--
+		case err = <-queueToConnChan:
+			remainingDirections--
+		case err = <-streamToQueueChan:
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (p *pipe) send(frame *proto.TunnelFrame) error {
+	p.sendMutex.Lock()
+	defer p.sendMutex.Unlock()
+
+	return p.stream.SendFrame(frame)
+}
+
+func (p *pipe) forwardConnToStream() error {
+	buffer := make([]byte, frameDataSize)
+
+	for {
+		acquired, err := p.sendWindow.acquire(len(buffer))
+
+		if err != nil {
+			return err
+		}
+
+		n, readErr := p.conn.Read(buffer[:acquired])
+		p.sendWindow.release(acquired - n)
+
+		if n > 0 {
+			err := p.send(&proto.TunnelFrame{
+				Data: append([]byte{}, buffer[:n]...),
+			})
+
+			if err != nil {
+				return err
+			}
+		}
+
+		if readErr != nil && errors.Is(readErr, io.EOF) {
+			return p.send(&proto.TunnelFrame{
+				CloseWrite: true,
+			})
+		}
+
+		if readErr != nil {
+			return readErr
+		}
+	}
+}
+
+// forwardStreamToQueue returns nil once the peer
+// has closed its sending side. Must not block
+// on the connection to keep receiving window updates.
+func (p *pipe) forwardStreamToQueue() error {
+	for {
+		frame, err := p.stream.RecvFrame()
+
+		if err != nil && errors.Is(err, io.EOF) {
+			p.sendWindow.unbound()
+			p.writeQueue.pushCloseWrite()
+
+			return nil
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if frame.WindowUpdate > 0 {
+			p.sendWindow.release(int(frame.WindowUpdate))
+		}
+
+		if len(frame.Data) > 0 {
+			p.writeQueue.push(frame.Data)
+		}
+
+		if frame.CloseWrite {
+			p.writeQueue.pushCloseWrite()
+		}
+	}
+}
+
+func (p *pipe) forwardQueueToConn() error {
+	for {
+		data, closeWrite, ok := p.writeQueue.pop()
+
+		if !ok {
+			return errWindowClosed
+		}
+
+		if closeWrite {
+			return closeConnWrite(p.conn)
+		}
+
+		if _, err := p.conn.Write(data); err != nil {
+			return err
+		}
+
+		err := p.send(&proto.TunnelFrame{
+			WindowUpdate: uint32(len(data)),
+		})
+
+		if err != nil {
+			return err
+		}
+	}
+}
+
+type closeWriter interface {
+	CloseWrite() error
+}
+
+func closeConnWrite(conn net.Conn) error {
+	connCloseWriter, ok := conn.(closeWriter)
+
+	if !ok {
+		return nil
+	}
+
+	return connCloseWriter.CloseWrite()
+}
@@ -0,0 +1,73 @@
+package tunnel
+
+import "sync"
+
+// writeQueue buffers the bytes received for a connection
+// so that a slow connection doesn't block the whole stream.
+// Its size is bounded by the flow control window.
+type writeQueue struct {
+	mutex      sync.Mutex
+	cond       *sync.Cond
+	chunks     [][]byte
+	closeWrite bool
+	closed     bool
+}
+
+func newWriteQueue() *writeQueue {
+	queue := &writeQueue{
+		chunks: [][]byte{},
+	}
+
+	queue.cond = sync.NewCond(&queue.mutex)
+
+	return queue
+}
+
+func (w *writeQueue) push(data []byte) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	w.chunks = append(w.chunks, data)
+	w.cond.Broadcast()
+}
+
+func (w *writeQueue) pushCloseWrite() {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	w.closeWrite = true
+	w.cond.Broadcast()
+}
+
+func (w *writeQueue) close() {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	w.closed = true
+	w.cond.Broadcast()
+}
+
+// pop blocks until data is available. "closeWrite"
+// is returned once all the pending data was popped.
+// "ok" is false once the queue is closed.
+func (w *writeQueue) pop() (data []byte, closeWrite bool, ok bool) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	for len(w.chunks) == 0 && !w.closeWrite && !w.closed {
+		w.cond.Wait()
+	}
+
+	if w.closed {
+		return nil, false, false
+	}
+
+	if len(w.chunks) == 0 {
+		return nil, true, true
+	}
+
+	data = w.chunks[0]
+	w.chunks = w.chunks[1:]
+
+	return data, false, true
+}
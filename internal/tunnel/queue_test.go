@@ -0,0 +1,118 @@
+package tunnel
+
+import (
+	"testing"
+	"time"
+)
+
+type writeQueueOp struct {
+	data       string
+	closeWrite bool
+	close      bool
+}
+
+type writeQueuePop struct {
+	data       string
+	closeWrite bool
+	ok         bool
+}
+
+func TestWriteQueuePop(t *testing.T) {
+	testCases := []struct {
+		name         string
+		ops          []writeQueueOp
+		expectedPops []writeQueuePop
+	}{
+		{
+			name: "keeps the order",
+			ops: []writeQueueOp{
+				{data: "a"},
+				{data: "b"},
+			},
+			expectedPops: []writeQueuePop{
+				{data: "a", ok: true},
+				{data: "b", ok: true},
+			},
+		},
+		{
+			name: "close write after the pending data",
+			ops: []writeQueueOp{
+				{data: "a"},
+				{closeWrite: true},
+			},
+			expectedPops: []writeQueuePop{
+				{data: "a", ok: true},
+				{closeWrite: true, ok: true},
+				{closeWrite: true, ok: true},
+			},
+		},
+		{
+			name: "close drops the pending data",
+			ops: []writeQueueOp{
+				{data: "a"},
+				{close: true},
+			},
+			expectedPops: []writeQueuePop{
+				{ok: false},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			queue := newWriteQueue()
+
+			for _, op := range tc.ops {
+				switch {
+				case op.close:
+					queue.close()
+				case op.closeWrite:
+					queue.pushCloseWrite()
+				default:
+					queue.push([]byte(op.data))
+				}
+			}
+
+			for i, expectedPop := range tc.expectedPops {
+				data, closeWrite, ok := queue.pop()
+
+				pop := writeQueuePop{
+					data:       string(data),
+					closeWrite: closeWrite,
+					ok:         ok,
+				}
+
+				if pop != expectedPop {
+					t.Fatalf("pop %d: expected %+v, got %+v", i, expectedPop, pop)
+				}
+			}
+		})
+	}
+}
+
+func TestWriteQueuePopBlocksUntilPush(t *testing.T) {
+	queue := newWriteQueue()
+	popChan := make(chan string, 1)
+
+	go func() {
+		data, _, _ := queue.pop()
+		popChan <- string(data)
+	}()
+
+	select {
+	case <-popChan:
+		t.Fatal("expected pop to block on an empty queue")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	queue.push([]byte("a"))
+
+	select {
+	case data := <-popChan:
+		if data != "a" {
+			t.Fatalf("expected \"a\", got %q", data)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("pop still blocked")
+	}
+}
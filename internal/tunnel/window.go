@@ -0,0 +1,92 @@
+package tunnel
+
+import (
+	"errors"
+	"sync"
+)
+
+// The number of bytes that could be sent for
+// a connection before the peer acknowledges them
+// (by sending a window update once written).
+const connWindowSize = 256 * 1024
+
+var errWindowClosed = errors.New("tunnel window closed")
+
+// sendWindow implements a credit-based flow control:
+// senders block until the peer has consumed
+// enough of the previously sent bytes.
+type sendWindow struct {
+	mutex     sync.Mutex
+	cond      *sync.Cond
+	available int
+	unbounded bool
+	closed    bool
+}
+
+func newSendWindow(size int) *sendWindow {
+	window := &sendWindow{
+		available: size,
+	}
+
+	window.cond = sync.NewCond(&window.mutex)
+
+	return window
+}
+
+// acquire waits for credits and returns
+// the number of bytes (up to "max") that could be sent.
+func (s *sendWindow) acquire(max int) (int, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for s.available == 0 && !s.unbounded && !s.closed {
+		s.cond.Wait()
+	}
+
+	if s.closed {
+		return 0, errWindowClosed
+	}
+
+	if s.unbounded {
+		return max, nil
+	}
+
+	acquired := max
+
+	if s.available < acquired {
+		acquired = s.available
+	}
+
+	s.available -= acquired
+
+	return acquired, nil
+}
+
+// release gives back the credits not
+// used by the last acquire call.
+func (s *sendWindow) release(n int) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.available += n
+	s.cond.Broadcast()
+}
+
+// unbound disables the flow control (eg: when the peer
+// could not send window updates anymore). The sender then
+// only relies on the gRPC stream flow control.
+func (s *sendWindow) unbound() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.unbounded = true
+	s.cond.Broadcast()
+}
+
+func (s *sendWindow) close() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.closed = true
+	s.cond.Broadcast()
+}
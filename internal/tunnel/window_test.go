@@ -0,0 +1,128 @@
+package tunnel
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestSendWindowAcquire(t *testing.T) {
+	testCases := []struct {
+		name          string
+		size          int
+		acquireMaxes  []int
+		releases      []int
+		expectedSizes []int
+	}{
+		{
+			name:          "acquires up to max",
+			size:          100,
+			acquireMaxes:  []int{10, 20},
+			releases:      []int{0, 0},
+			expectedSizes: []int{10, 20},
+		},
+		{
+			name:          "acquires what is left",
+			size:          100,
+			acquireMaxes:  []int{80, 80},
+			releases:      []int{0, 0},
+			expectedSizes: []int{80, 20},
+		},
+		{
+			name:          "reuses released credits",
+			size:          100,
+			acquireMaxes:  []int{100, 50},
+			releases:      []int{60, 0},
+			expectedSizes: []int{100, 50},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			window := newSendWindow(tc.size)
+
+			for i, acquireMax := range tc.acquireMaxes {
+				acquired, err := window.acquire(acquireMax)
+
+				if err != nil {
+					t.Fatalf("acquire %d: unexpected error %v", i, err)
+				}
+
+				if acquired != tc.expectedSizes[i] {
+					t.Fatalf(
+						"acquire %d: expected %d bytes, got %d",
+						i,
+						tc.expectedSizes[i],
+						acquired,
+					)
+				}
+
+				window.release(tc.releases[i])
+			}
+		})
+	}
+}
+
+func TestSendWindowBlocksUntil(t *testing.T) {
+	testCases := []struct {
+		name          string
+		unblock       func(window *sendWindow)
+		expectedSize  int
+		expectedError error
+	}{
+		{
+			name:         "release",
+			unblock:      func(window *sendWindow) { window.release(5) },
+			expectedSize: 5,
+		},
+		{
+			name:         "unbound",
+			unblock:      func(window *sendWindow) { window.unbound() },
+			expectedSize: 10,
+		},
+		{
+			name:          "close",
+			unblock:       func(window *sendWindow) { window.close() },
+			expectedError: errWindowClosed,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			window := newSendWindow(0)
+
+			type acquireResult struct {
+				size int
+				err  error
+			}
+
+			resultChan := make(chan acquireResult, 1)
+
+			go func() {
+				size, err := window.acquire(10)
+				resultChan <- acquireResult{size, err}
+			}()
+
+			select {
+			case <-resultChan:
+				t.Fatal("expected acquire to block on an empty window")
+			case <-time.After(20 * time.Millisecond):
+			}
+
+			tc.unblock(window)
+
+			select {
+			case result := <-resultChan:
+				if !errors.Is(result.err, tc.expectedError) {
+					t.Fatalf("expected error %v, got %v", tc.expectedError, result.err)
+				}
+
+				if result.size != tc.expectedSize {
+					t.Fatalf("expected %d bytes, got %d", tc.expectedSize, result.size)
+				}
+			case <-time.After(time.Second):
+				t.Fatal("acquire still blocked")
+			}
+		})
+	}
+}
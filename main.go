@@ -1,51 +1,277 @@
 package main
 
 import (
-	"log"
+	"fmt"
+	"io"
 	"os"
-	"time"
+	"path/filepath"
 
 	"github.com/yolo-sh/agent-container/constants"
+	"github.com/yolo-sh/agent-container/internal/config"
+	"github.com/yolo-sh/agent-container/internal/env"
+	"github.com/yolo-sh/agent-container/internal/gitcredentials"
 	"github.com/yolo-sh/agent-container/internal/grpcserver"
+	"github.com/yolo-sh/agent-container/internal/initrun"
+	"github.com/yolo-sh/agent-container/internal/logging"
+	"github.com/yolo-sh/agent-container/internal/metrics"
 	"github.com/yolo-sh/agent-container/internal/network"
 )
 
+var logger = logging.Component(logging.ComponentMain)
+
+// Closed by exitWithError given that
+// "os.Exit" doesn't run the deferred calls
+var logFile io.Closer
+
 func main() {
+	// The agent binary is also the git credential helper
+	// (see "gitcredentials.InstallHelper")
+	if filepath.Base(os.Args[0]) == gitcredentials.HelperName {
+		runGitCredentialHelper(os.Args[1:])
+		return
+	}
+
+	agentConfig, err := config.Load()
+
+	if err != nil {
+		exitWithError("error when loading config", err)
+	}
+
+	logFile, err = logging.Setup(logging.Config{
+		Level:          agentConfig.LogLevel,
+		Format:         agentConfig.LogFormat,
+		FilePath:       agentConfig.LogFilePath,
+		FileMaxSize:    int64(agentConfig.LogFileMaxSizeMB) * 1024 * 1024,
+		FileMaxBackups: agentConfig.LogFileMaxBackups,
+		BufferSize:     agentConfig.LogBufferSize,
+	})
+
+	if err != nil {
+		exitWithError("error when setting up logging", err)
+	}
+
+	defer logFile.Close()
+
+	// A socket left by a previous agent process would make
+	// the shells use a dead SSH agent (see "ForwardSSHAgent")
+	if err := os.RemoveAll(constants.SSHAgentSocketPath); err != nil {
+		logger.Warn(
+			"error when removing old SSH agent socket",
+			"path", constants.SSHAgentSocketPath,
+			"error", err,
+		)
+	}
+
 	// Prevent "bind: address already in use" error
-	err := ensureOldGRPCServerSocketRemoved(constants.GRPCServerAddr)
+	err = ensureOldGRPCServerSocketRemoved(constants.GRPCServerAddr)
+
+	if err != nil {
+		exitWithError("error when removing old gRPC server socket", err)
+	}
+
+	if len(agentConfig.MetricsServerAddr) > 0 {
+		go func() {
+			logger.Info(
+				"metrics server listening",
+				"addr", agentConfig.MetricsServerAddr,
+			)
+
+			err := metrics.ListenAndServe(agentConfig.MetricsServerAddr)
 
+			if err != nil {
+				exitWithError("metrics server error", err)
+			}
+		}()
+	}
+
+	proxyManagerConfig := network.NewDefaultProxyManagerConfig()
+
+	proxyManagerConfig.Forwarding = network.ForwardingConfig{
+		LingerTimeout:   agentConfig.ProxyLingerTimeout,
+		IdleTimeout:     agentConfig.ProxyIdleTimeout,
+		KeepAlivePeriod: agentConfig.ProxyKeepAlivePeriod,
+	}
+
+	proxyManagerConfig.UnixSocketsDirs = agentConfig.UnixSocketsDirs
+	proxyManagerConfig.BindAddrs = agentConfig.ProxyBindAddrs
+	proxyManagerConfig.SendProxyProtocolPorts = agentConfig.SendProxyProtocolPorts
+	proxyManagerConfig.AcceptProxyProtocol = agentConfig.AcceptProxyProtocol
+
+	proxyManagerConfig.ConnLimits = network.ConnLimitsConfig{
+		MaxConnsPerPort:    agentConfig.ProxyMaxConnsPerPort,
+		MaxFDsPerPort:      agentConfig.ProxyMaxFDsPerPort,
+		MaxFDsTotal:        agentConfig.ProxyMaxFDsTotal,
+		AcceptRatePerPort:  agentConfig.ProxyAcceptRatePerPort,
+		AcceptBurstPerPort: agentConfig.ProxyAcceptBurstPerPort,
+	}
+
+	workspaceCA, err := network.LoadOrCreateWorkspaceCA(
+		constants.WorkspaceCACertFilePath,
+		constants.WorkspaceCAKeyFilePath,
+	)
+
+	// TLS termination is optional: the rest
+	// of the agent could run without the CA
 	if err != nil {
-		log.Fatalf("%v", err)
+		logger.Error(
+			"error when loading workspace CA, TLS termination disabled",
+			"error", err,
+		)
+
+		workspaceCA = nil
+	}
+
+	proxyManagerConfig.WorkspaceCA = workspaceCA
+
+	proxyManager := network.NewProxyManager(proxyManagerConfig)
+
+	logger.Info("polling proxies state")
+
+	if err := proxyManager.Start(); err != nil {
+		exitWithError("error when starting proxy manager", err)
 	}
 
 	go func() {
-		log.Printf(
-			"Polling proxies state...",
+		if err := proxyManager.Wait(); err != nil {
+			exitWithError("proxy manager error", err)
+		}
+	}()
+
+	httpProxy := network.NewHTTPProxy(
+		network.HTTPProxyConfig{
+			WorkspaceName: agentConfig.HTTPProxyWorkspaceName,
+		},
+		proxyManager,
+	)
+
+	if len(agentConfig.HTTPProxyAddr) > 0 {
+		go func() {
+			logger.Info(
+				"HTTP proxy listening",
+				"addr", agentConfig.HTTPProxyAddr,
+			)
+
+			err := httpProxy.ListenAndServe(agentConfig.HTTPProxyAddr)
+
+			if err != nil {
+				exitWithError("HTTP proxy error", err)
+			}
+		}()
+	}
+
+	if len(agentConfig.HTTPSProxyAddr) > 0 && workspaceCA == nil {
+		logger.Warn(
+			"HTTPS proxy not started",
+			"addr", agentConfig.HTTPSProxyAddr,
+			"error", network.ErrTLSTerminationDisabled,
 		)
+	}
+
+	if len(agentConfig.HTTPSProxyAddr) > 0 && workspaceCA != nil {
+		go func() {
+			logger.Info(
+				"HTTPS proxy listening",
+				"addr", agentConfig.HTTPSProxyAddr,
+			)
 
-		for {
-			err := network.ReconcileLocalhostProxiesState()
+			err := httpProxy.ListenAndServeTLS(
+				agentConfig.HTTPSProxyAddr,
+				workspaceCA,
+			)
 
 			if err != nil {
-				log.Fatalf("%v", err)
+				exitWithError("HTTPS proxy error", err)
 			}
+		}()
+	}
+
+	logger.Info(
+		"gRPC server listening",
+		"addr", constants.GRPCServerUri,
+	)
+
+	gitCredentialsStore := gitcredentials.NewStore()
+	gitCredentialsBroker := gitcredentials.NewHostBroker()
+
+	err = gitcredentials.InstallHelper(constants.GitCredentialHelperPath)
+
+	if err != nil {
+		logger.Warn(
+			"error when installing git credential helper",
+			"path", constants.GitCredentialHelperPath,
+			"error", err,
+		)
+	}
+
+	go func() {
+		logger.Info(
+			"git credentials server listening",
+			"addr", constants.GitCredentialsSocketPath,
+		)
+
+		err := gitcredentials.ListenAndServe(
+			constants.GitCredentialsSocketPath,
+			gitCredentialsStore,
+			gitCredentialsBroker,
+		)
 
-			time.Sleep(60 * time.Millisecond)
+		if err != nil {
+			exitWithError("git credentials server error", err)
 		}
 	}()
 
-	log.Printf(
-		"GRPC server listening at %s",
-		constants.GRPCServerUri,
-	)
+	grpcServerConfig := grpcserver.NewDefaultServerConfig()
+
+	grpcServerConfig.GitAccessCheckPolicy = env.GitAccessCheckPolicy{
+		MaxAttempts:    agentConfig.GitAccessCheckMaxAttempts,
+		InitialBackoff: agentConfig.GitAccessCheckInitialBackoff,
+		MaxBackoff:     agentConfig.GitAccessCheckMaxBackoff,
+	}
 
 	err = grpcserver.ListenAndServe(
 		constants.GRPCServerAddrProtocol,
 		constants.GRPCServerAddr,
+		grpcServerConfig,
+		proxyManager,
+		initrun.NewManager(constants.InitRunsDirPath),
+		gitCredentialsStore,
+		gitCredentialsBroker,
+	)
+
+	if err != nil {
+		exitWithError("gRPC server error", err)
+	}
+}
+
+func exitWithError(msg string, err error) {
+	logger.Error(msg, "error", err)
+
+	if logFile != nil {
+		logFile.Close()
+	}
+
+	os.Exit(1)
+}
+
+// Run by git with the operation ("get", "store"
+// or "erase") as argument (see "git help credential")
+func runGitCredentialHelper(args []string) {
+	operation := ""
+
+	if len(args) > 0 {
+		operation = args[0]
+	}
+
+	err := gitcredentials.RunHelper(
+		constants.GitCredentialsSocketPath,
+		operation,
+		os.Stdin,
+		os.Stdout,
 	)
 
 	if err != nil {
-		log.Fatalf("%v", err)
+		fmt.Fprintf(os.Stderr, "yolo git credential helper: %v\n", err)
+		os.Exit(1)
 	}
 }
 
@@ -20,6 +20,21 @@ const _ = grpc.SupportPackageIsVersion7
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type AgentClient interface {
 	Init(ctx context.Context, in *InitRequest, opts ...grpc.CallOption) (Agent_InitClient, error)
+	GetInitRun(ctx context.Context, in *GetInitRunRequest, opts ...grpc.CallOption) (*GetInitRunReply, error)
+	AttachInitRun(ctx context.Context, in *AttachInitRunRequest, opts ...grpc.CallOption) (Agent_AttachInitRunClient, error)
+	GetMetrics(ctx context.Context, in *GetMetricsRequest, opts ...grpc.CallOption) (*GetMetricsReply, error)
+	GetProxiesStats(ctx context.Context, in *GetProxiesStatsRequest, opts ...grpc.CallOption) (*GetProxiesStatsReply, error)
+	ExposePort(ctx context.Context, in *ExposePortRequest, opts ...grpc.CallOption) (*ExposePortReply, error)
+	UnexposePort(ctx context.Context, in *UnexposePortRequest, opts ...grpc.CallOption) (*UnexposePortReply, error)
+	ListForwardedPorts(ctx context.Context, in *ListForwardedPortsRequest, opts ...grpc.CallOption) (*ListForwardedPortsReply, error)
+	ListUnixSockets(ctx context.Context, in *ListUnixSocketsRequest, opts ...grpc.CallOption) (*ListUnixSocketsReply, error)
+	DialSocket(ctx context.Context, opts ...grpc.CallOption) (Agent_DialSocketClient, error)
+	ReverseTunnel(ctx context.Context, opts ...grpc.CallOption) (Agent_ReverseTunnelClient, error)
+	DialTCP(ctx context.Context, opts ...grpc.CallOption) (Agent_DialTCPClient, error)
+	GetCACertificate(ctx context.Context, in *GetCACertificateRequest, opts ...grpc.CallOption) (*GetCACertificateReply, error)
+	StreamLogs(ctx context.Context, in *StreamLogsRequest, opts ...grpc.CallOption) (Agent_StreamLogsClient, error)
+	CredentialRequests(ctx context.Context, opts ...grpc.CallOption) (Agent_CredentialRequestsClient, error)
+	ForwardSSHAgent(ctx context.Context, opts ...grpc.CallOption) (Agent_ForwardSSHAgentClient, error)
 }
 
 type agentClient struct {
@@ -62,11 +77,317 @@ func (x *agentInitClient) Recv() (*InitReply, error) {
 	return m, nil
 }
 
+func (c *agentClient) GetInitRun(ctx context.Context, in *GetInitRunRequest, opts ...grpc.CallOption) (*GetInitRunReply, error) {
+	out := new(GetInitRunReply)
+	err := c.cc.Invoke(ctx, "/yolo.agent_container.Agent/GetInitRun", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentClient) AttachInitRun(ctx context.Context, in *AttachInitRunRequest, opts ...grpc.CallOption) (Agent_AttachInitRunClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Agent_ServiceDesc.Streams[1], "/yolo.agent_container.Agent/AttachInitRun", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &agentAttachInitRunClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Agent_AttachInitRunClient interface {
+	Recv() (*InitReply, error)
+	grpc.ClientStream
+}
+
+type agentAttachInitRunClient struct {
+	grpc.ClientStream
+}
+
+func (x *agentAttachInitRunClient) Recv() (*InitReply, error) {
+	m := new(InitReply)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *agentClient) GetMetrics(ctx context.Context, in *GetMetricsRequest, opts ...grpc.CallOption) (*GetMetricsReply, error) {
+	out := new(GetMetricsReply)
+	err := c.cc.Invoke(ctx, "/yolo.agent_container.Agent/GetMetrics", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentClient) GetProxiesStats(ctx context.Context, in *GetProxiesStatsRequest, opts ...grpc.CallOption) (*GetProxiesStatsReply, error) {
+	out := new(GetProxiesStatsReply)
+	err := c.cc.Invoke(ctx, "/yolo.agent_container.Agent/GetProxiesStats", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentClient) ExposePort(ctx context.Context, in *ExposePortRequest, opts ...grpc.CallOption) (*ExposePortReply, error) {
+	out := new(ExposePortReply)
+	err := c.cc.Invoke(ctx, "/yolo.agent_container.Agent/ExposePort", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentClient) UnexposePort(ctx context.Context, in *UnexposePortRequest, opts ...grpc.CallOption) (*UnexposePortReply, error) {
+	out := new(UnexposePortReply)
+	err := c.cc.Invoke(ctx, "/yolo.agent_container.Agent/UnexposePort", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentClient) ListForwardedPorts(ctx context.Context, in *ListForwardedPortsRequest, opts ...grpc.CallOption) (*ListForwardedPortsReply, error) {
+	out := new(ListForwardedPortsReply)
+	err := c.cc.Invoke(ctx, "/yolo.agent_container.Agent/ListForwardedPorts", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentClient) ListUnixSockets(ctx context.Context, in *ListUnixSocketsRequest, opts ...grpc.CallOption) (*ListUnixSocketsReply, error) {
+	out := new(ListUnixSocketsReply)
+	err := c.cc.Invoke(ctx, "/yolo.agent_container.Agent/ListUnixSockets", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentClient) DialSocket(ctx context.Context, opts ...grpc.CallOption) (Agent_DialSocketClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Agent_ServiceDesc.Streams[2], "/yolo.agent_container.Agent/DialSocket", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &agentDialSocketClient{stream}
+	return x, nil
+}
+
+type Agent_DialSocketClient interface {
+	Send(*DialSocketRequest) error
+	Recv() (*DialSocketReply, error)
+	grpc.ClientStream
+}
+
+type agentDialSocketClient struct {
+	grpc.ClientStream
+}
+
+func (x *agentDialSocketClient) Send(m *DialSocketRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *agentDialSocketClient) Recv() (*DialSocketReply, error) {
+	m := new(DialSocketReply)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *agentClient) ReverseTunnel(ctx context.Context, opts ...grpc.CallOption) (Agent_ReverseTunnelClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Agent_ServiceDesc.Streams[3], "/yolo.agent_container.Agent/ReverseTunnel", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &agentReverseTunnelClient{stream}
+	return x, nil
+}
+
+type Agent_ReverseTunnelClient interface {
+	Send(*ReverseTunnelRequest) error
+	Recv() (*ReverseTunnelReply, error)
+	grpc.ClientStream
+}
+
+type agentReverseTunnelClient struct {
+	grpc.ClientStream
+}
+
+func (x *agentReverseTunnelClient) Send(m *ReverseTunnelRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *agentReverseTunnelClient) Recv() (*ReverseTunnelReply, error) {
+	m := new(ReverseTunnelReply)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *agentClient) DialTCP(ctx context.Context, opts ...grpc.CallOption) (Agent_DialTCPClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Agent_ServiceDesc.Streams[4], "/yolo.agent_container.Agent/DialTCP", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &agentDialTCPClient{stream}
+	return x, nil
+}
+
+type Agent_DialTCPClient interface {
+	Send(*DialTCPRequest) error
+	Recv() (*DialTCPReply, error)
+	grpc.ClientStream
+}
+
+type agentDialTCPClient struct {
+	grpc.ClientStream
+}
+
+func (x *agentDialTCPClient) Send(m *DialTCPRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *agentDialTCPClient) Recv() (*DialTCPReply, error) {
+	m := new(DialTCPReply)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *agentClient) GetCACertificate(ctx context.Context, in *GetCACertificateRequest, opts ...grpc.CallOption) (*GetCACertificateReply, error) {
+	out := new(GetCACertificateReply)
+	err := c.cc.Invoke(ctx, "/yolo.agent_container.Agent/GetCACertificate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentClient) StreamLogs(ctx context.Context, in *StreamLogsRequest, opts ...grpc.CallOption) (Agent_StreamLogsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Agent_ServiceDesc.Streams[5], "/yolo.agent_container.Agent/StreamLogs", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &agentStreamLogsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Agent_StreamLogsClient interface {
+	Recv() (*StreamLogsReply, error)
+	grpc.ClientStream
+}
+
+type agentStreamLogsClient struct {
+	grpc.ClientStream
+}
+
+func (x *agentStreamLogsClient) Recv() (*StreamLogsReply, error) {
+	m := new(StreamLogsReply)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *agentClient) CredentialRequests(ctx context.Context, opts ...grpc.CallOption) (Agent_CredentialRequestsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Agent_ServiceDesc.Streams[6], "/yolo.agent_container.Agent/CredentialRequests", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &agentCredentialRequestsClient{stream}
+	return x, nil
+}
+
+type Agent_CredentialRequestsClient interface {
+	Send(*CredentialRequestsRequest) error
+	Recv() (*CredentialRequestsReply, error)
+	grpc.ClientStream
+}
+
+type agentCredentialRequestsClient struct {
+	grpc.ClientStream
+}
+
+func (x *agentCredentialRequestsClient) Send(m *CredentialRequestsRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *agentCredentialRequestsClient) Recv() (*CredentialRequestsReply, error) {
+	m := new(CredentialRequestsReply)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *agentClient) ForwardSSHAgent(ctx context.Context, opts ...grpc.CallOption) (Agent_ForwardSSHAgentClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Agent_ServiceDesc.Streams[7], "/yolo.agent_container.Agent/ForwardSSHAgent", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &agentForwardSSHAgentClient{stream}
+	return x, nil
+}
+
+type Agent_ForwardSSHAgentClient interface {
+	Send(*ForwardSSHAgentRequest) error
+	Recv() (*ForwardSSHAgentReply, error)
+	grpc.ClientStream
+}
+
+type agentForwardSSHAgentClient struct {
+	grpc.ClientStream
+}
+
+func (x *agentForwardSSHAgentClient) Send(m *ForwardSSHAgentRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *agentForwardSSHAgentClient) Recv() (*ForwardSSHAgentReply, error) {
+	m := new(ForwardSSHAgentReply)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // AgentServer is the server API for Agent service.
 // All implementations must embed UnimplementedAgentServer
 // for forward compatibility
 type AgentServer interface {
 	Init(*InitRequest, Agent_InitServer) error
+	GetInitRun(context.Context, *GetInitRunRequest) (*GetInitRunReply, error)
+	AttachInitRun(*AttachInitRunRequest, Agent_AttachInitRunServer) error
+	GetMetrics(context.Context, *GetMetricsRequest) (*GetMetricsReply, error)
+	GetProxiesStats(context.Context, *GetProxiesStatsRequest) (*GetProxiesStatsReply, error)
+	ExposePort(context.Context, *ExposePortRequest) (*ExposePortReply, error)
+	UnexposePort(context.Context, *UnexposePortRequest) (*UnexposePortReply, error)
+	ListForwardedPorts(context.Context, *ListForwardedPortsRequest) (*ListForwardedPortsReply, error)
+	ListUnixSockets(context.Context, *ListUnixSocketsRequest) (*ListUnixSocketsReply, error)
+	DialSocket(Agent_DialSocketServer) error
+	ReverseTunnel(Agent_ReverseTunnelServer) error
+	DialTCP(Agent_DialTCPServer) error
+	GetCACertificate(context.Context, *GetCACertificateRequest) (*GetCACertificateReply, error)
+	StreamLogs(*StreamLogsRequest, Agent_StreamLogsServer) error
+	CredentialRequests(Agent_CredentialRequestsServer) error
+	ForwardSSHAgent(Agent_ForwardSSHAgentServer) error
 	mustEmbedUnimplementedAgentServer()
 }
 
@@ -77,6 +398,51 @@ type UnimplementedAgentServer struct {
 func (UnimplementedAgentServer) Init(*InitRequest, Agent_InitServer) error {
 	return status.Errorf(codes.Unimplemented, "method Init not implemented")
 }
+func (UnimplementedAgentServer) GetInitRun(context.Context, *GetInitRunRequest) (*GetInitRunReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetInitRun not implemented")
+}
+func (UnimplementedAgentServer) AttachInitRun(*AttachInitRunRequest, Agent_AttachInitRunServer) error {
+	return status.Errorf(codes.Unimplemented, "method AttachInitRun not implemented")
+}
+func (UnimplementedAgentServer) GetMetrics(context.Context, *GetMetricsRequest) (*GetMetricsReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMetrics not implemented")
+}
+func (UnimplementedAgentServer) GetProxiesStats(context.Context, *GetProxiesStatsRequest) (*GetProxiesStatsReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetProxiesStats not implemented")
+}
+func (UnimplementedAgentServer) ExposePort(context.Context, *ExposePortRequest) (*ExposePortReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExposePort not implemented")
+}
+func (UnimplementedAgentServer) UnexposePort(context.Context, *UnexposePortRequest) (*UnexposePortReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnexposePort not implemented")
+}
+func (UnimplementedAgentServer) ListForwardedPorts(context.Context, *ListForwardedPortsRequest) (*ListForwardedPortsReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListForwardedPorts not implemented")
+}
+func (UnimplementedAgentServer) ListUnixSockets(context.Context, *ListUnixSocketsRequest) (*ListUnixSocketsReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListUnixSockets not implemented")
+}
+func (UnimplementedAgentServer) DialSocket(Agent_DialSocketServer) error {
+	return status.Errorf(codes.Unimplemented, "method DialSocket not implemented")
+}
+func (UnimplementedAgentServer) ReverseTunnel(Agent_ReverseTunnelServer) error {
+	return status.Errorf(codes.Unimplemented, "method ReverseTunnel not implemented")
+}
+func (UnimplementedAgentServer) DialTCP(Agent_DialTCPServer) error {
+	return status.Errorf(codes.Unimplemented, "method DialTCP not implemented")
+}
+func (UnimplementedAgentServer) GetCACertificate(context.Context, *GetCACertificateRequest) (*GetCACertificateReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCACertificate not implemented")
+}
+func (UnimplementedAgentServer) StreamLogs(*StreamLogsRequest, Agent_StreamLogsServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamLogs not implemented")
+}
+func (UnimplementedAgentServer) CredentialRequests(Agent_CredentialRequestsServer) error {
+	return status.Errorf(codes.Unimplemented, "method CredentialRequests not implemented")
+}
+func (UnimplementedAgentServer) ForwardSSHAgent(Agent_ForwardSSHAgentServer) error {
+	return status.Errorf(codes.Unimplemented, "method ForwardSSHAgent not implemented")
+}
 func (UnimplementedAgentServer) mustEmbedUnimplementedAgentServer() {}
 
 // UnsafeAgentServer may be embedded to opt out of forward compatibility for this service.
@@ -111,19 +477,408 @@ func (x *agentInitServer) Send(m *InitReply) error {
 	return x.ServerStream.SendMsg(m)
 }
 
+func _Agent_GetInitRun_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetInitRunRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentServer).GetInitRun(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/yolo.agent_container.Agent/GetInitRun",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServer).GetInitRun(ctx, req.(*GetInitRunRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Agent_AttachInitRun_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(AttachInitRunRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(AgentServer).AttachInitRun(m, &agentAttachInitRunServer{stream})
+}
+
+type Agent_AttachInitRunServer interface {
+	Send(*InitReply) error
+	grpc.ServerStream
+}
+
+type agentAttachInitRunServer struct {
+	grpc.ServerStream
+}
+
+func (x *agentAttachInitRunServer) Send(m *InitReply) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Agent_GetMetrics_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetMetricsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentServer).GetMetrics(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/yolo.agent_container.Agent/GetMetrics",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServer).GetMetrics(ctx, req.(*GetMetricsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Agent_GetProxiesStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetProxiesStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentServer).GetProxiesStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/yolo.agent_container.Agent/GetProxiesStats",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServer).GetProxiesStats(ctx, req.(*GetProxiesStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Agent_ExposePort_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExposePortRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentServer).ExposePort(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/yolo.agent_container.Agent/ExposePort",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServer).ExposePort(ctx, req.(*ExposePortRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Agent_UnexposePort_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnexposePortRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentServer).UnexposePort(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/yolo.agent_container.Agent/UnexposePort",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServer).UnexposePort(ctx, req.(*UnexposePortRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Agent_ListForwardedPorts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListForwardedPortsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentServer).ListForwardedPorts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/yolo.agent_container.Agent/ListForwardedPorts",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServer).ListForwardedPorts(ctx, req.(*ListForwardedPortsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Agent_ListUnixSockets_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListUnixSocketsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentServer).ListUnixSockets(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/yolo.agent_container.Agent/ListUnixSockets",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServer).ListUnixSockets(ctx, req.(*ListUnixSocketsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Agent_DialSocket_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(AgentServer).DialSocket(&agentDialSocketServer{stream})
+}
+
+type Agent_DialSocketServer interface {
+	Send(*DialSocketReply) error
+	Recv() (*DialSocketRequest, error)
+	grpc.ServerStream
+}
+
+type agentDialSocketServer struct {
+	grpc.ServerStream
+}
+
+func (x *agentDialSocketServer) Send(m *DialSocketReply) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *agentDialSocketServer) Recv() (*DialSocketRequest, error) {
+	m := new(DialSocketRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _Agent_ReverseTunnel_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(AgentServer).ReverseTunnel(&agentReverseTunnelServer{stream})
+}
+
+type Agent_ReverseTunnelServer interface {
+	Send(*ReverseTunnelReply) error
+	Recv() (*ReverseTunnelRequest, error)
+	grpc.ServerStream
+}
+
+type agentReverseTunnelServer struct {
+	grpc.ServerStream
+}
+
+func (x *agentReverseTunnelServer) Send(m *ReverseTunnelReply) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *agentReverseTunnelServer) Recv() (*ReverseTunnelRequest, error) {
+	m := new(ReverseTunnelRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _Agent_DialTCP_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(AgentServer).DialTCP(&agentDialTCPServer{stream})
+}
+
+type Agent_DialTCPServer interface {
+	Send(*DialTCPReply) error
+	Recv() (*DialTCPRequest, error)
+	grpc.ServerStream
+}
+
+type agentDialTCPServer struct {
+	grpc.ServerStream
+}
+
+func (x *agentDialTCPServer) Send(m *DialTCPReply) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *agentDialTCPServer) Recv() (*DialTCPRequest, error) {
+	m := new(DialTCPRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _Agent_GetCACertificate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCACertificateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentServer).GetCACertificate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/yolo.agent_container.Agent/GetCACertificate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServer).GetCACertificate(ctx, req.(*GetCACertificateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Agent_StreamLogs_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamLogsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(AgentServer).StreamLogs(m, &agentStreamLogsServer{stream})
+}
+
+type Agent_StreamLogsServer interface {
+	Send(*StreamLogsReply) error
+	grpc.ServerStream
+}
+
+type agentStreamLogsServer struct {
+	grpc.ServerStream
+}
+
+func (x *agentStreamLogsServer) Send(m *StreamLogsReply) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Agent_CredentialRequests_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(AgentServer).CredentialRequests(&agentCredentialRequestsServer{stream})
+}
+
+type Agent_CredentialRequestsServer interface {
+	Send(*CredentialRequestsReply) error
+	Recv() (*CredentialRequestsRequest, error)
+	grpc.ServerStream
+}
+
+type agentCredentialRequestsServer struct {
+	grpc.ServerStream
+}
+
+func (x *agentCredentialRequestsServer) Send(m *CredentialRequestsReply) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *agentCredentialRequestsServer) Recv() (*CredentialRequestsRequest, error) {
+	m := new(CredentialRequestsRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _Agent_ForwardSSHAgent_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(AgentServer).ForwardSSHAgent(&agentForwardSSHAgentServer{stream})
+}
+
+type Agent_ForwardSSHAgentServer interface {
+	Send(*ForwardSSHAgentReply) error
+	Recv() (*ForwardSSHAgentRequest, error)
+	grpc.ServerStream
+}
+
+type agentForwardSSHAgentServer struct {
+	grpc.ServerStream
+}
+
+func (x *agentForwardSSHAgentServer) Send(m *ForwardSSHAgentReply) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *agentForwardSSHAgentServer) Recv() (*ForwardSSHAgentRequest, error) {
+	m := new(ForwardSSHAgentRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // Agent_ServiceDesc is the grpc.ServiceDesc for Agent service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
 var Agent_ServiceDesc = grpc.ServiceDesc{
 	ServiceName: "yolo.agent_container.Agent",
 	HandlerType: (*AgentServer)(nil),
-	Methods:     []grpc.MethodDesc{},
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetInitRun",
+			Handler:    _Agent_GetInitRun_Handler,
+		},
+		{
+			MethodName: "GetMetrics",
+			Handler:    _Agent_GetMetrics_Handler,
+		},
+		{
+			MethodName: "GetProxiesStats",
+			Handler:    _Agent_GetProxiesStats_Handler,
+		},
+		{
+			MethodName: "ExposePort",
+			Handler:    _Agent_ExposePort_Handler,
+		},
+		{
+			MethodName: "UnexposePort",
+			Handler:    _Agent_UnexposePort_Handler,
+		},
+		{
+			MethodName: "ListForwardedPorts",
+			Handler:    _Agent_ListForwardedPorts_Handler,
+		},
+		{
+			MethodName: "ListUnixSockets",
+			Handler:    _Agent_ListUnixSockets_Handler,
+		},
+		{
+			MethodName: "GetCACertificate",
+			Handler:    _Agent_GetCACertificate_Handler,
+		},
+	},
 	Streams: []grpc.StreamDesc{
 		{
 			StreamName:    "Init",
 			Handler:       _Agent_Init_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "AttachInitRun",
+			Handler:       _Agent_AttachInitRun_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "DialSocket",
+			Handler:       _Agent_DialSocket_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "ReverseTunnel",
+			Handler:       _Agent_ReverseTunnel_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "DialTCP",
+			Handler:       _Agent_DialTCP_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "StreamLogs",
+			Handler:       _Agent_StreamLogs_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "CredentialRequests",
+			Handler:       _Agent_CredentialRequests_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "ForwardSSHAgent",
+			Handler:       _Agent_ForwardSSHAgent_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
 	},
 	Metadata: "agent_container.proto",
 }